@@ -0,0 +1,68 @@
+package sudoku
+
+import "math/bits"
+
+// maxPuzzleSize is the largest puzzle size that can be represented by a bitset.
+const maxPuzzleSize = 64
+
+// bitset is a set of cell values.
+// Value n is stored in bit n-1, so a bitset can hold values 1 to maxPuzzleSize.
+type bitset uint64
+
+// fullBitset returns a bitset containing every value from 1 to puzzleSize.
+func fullBitset(puzzleSize int) bitset {
+	if puzzleSize >= maxPuzzleSize {
+		return ^bitset(0)
+	}
+	return bitset(1)<<uint(puzzleSize) - 1
+}
+
+// has returns true if the given value is in the bitset.
+func (b bitset) has(value int) bool {
+	return value > 0 && b&(1<<uint(value-1)) != 0
+}
+
+// with returns a copy of the bitset with the given value added.
+func (b bitset) with(value int) bitset {
+	if value <= 0 {
+		return b
+	}
+	return b | 1<<uint(value-1)
+}
+
+// without returns a copy of the bitset with the given value removed.
+func (b bitset) without(value int) bitset {
+	if value <= 0 {
+		return b
+	}
+	return b &^ (1 << uint(value-1))
+}
+
+// from returns a copy of the bitset without any values lower than minValue.
+func (b bitset) from(minValue int) bitset {
+	if minValue <= 1 {
+		return b
+	}
+	if minValue > maxPuzzleSize {
+		return 0
+	}
+	return b &^ (1<<uint(minValue-1) - 1)
+}
+
+// count returns the number of values in the bitset.
+func (b bitset) count() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+// lowest returns the lowest value in the bitset, or 0 if the bitset is empty.
+func (b bitset) lowest() int {
+	if b == 0 {
+		return 0
+	}
+	return bits.TrailingZeros64(uint64(b)) + 1
+}
+
+// highest returns the highest value in the bitset, or 0 if the bitset is empty.
+func (b bitset) highest() int {
+	return bits.Len64(uint64(b))
+}
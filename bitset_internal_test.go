@@ -0,0 +1,69 @@
+package sudoku
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	b := bitset(0).with(1).with(4).with(9)
+
+	if got := b.count(); got != 3 {
+		t.Errorf("expected count 3, got %d", got)
+	}
+	for _, v := range []int{1, 4, 9} {
+		if !b.has(v) {
+			t.Errorf("expected bitset to have %d", v)
+		}
+	}
+	for _, v := range []int{0, 2, 3, 5, 8, 10} {
+		if b.has(v) {
+			t.Errorf("expected bitset not to have %d", v)
+		}
+	}
+	if got := b.lowest(); got != 1 {
+		t.Errorf("expected lowest 1, got %d", got)
+	}
+	if got := b.from(2).lowest(); got != 4 {
+		t.Errorf("expected lowest from 2 to be 4, got %d", got)
+	}
+	if got := b.from(10); got != 0 {
+		t.Errorf("expected empty bitset from 10, got %b", got)
+	}
+	if got := b.without(4).without(1).lowest(); got != 9 {
+		t.Errorf("expected lowest 9, got %d", got)
+	}
+	if got := bitset(0).lowest(); got != 0 {
+		t.Errorf("expected lowest of empty bitset to be 0, got %d", got)
+	}
+	if got := b.highest(); got != 9 {
+		t.Errorf("expected highest 9, got %d", got)
+	}
+	if got := bitset(0).highest(); got != 0 {
+		t.Errorf("expected highest of empty bitset to be 0, got %d", got)
+	}
+}
+
+func TestFullBitset(t *testing.T) {
+	tests := []struct {
+		Size  int
+		Count int
+	}{
+		{Size: 4, Count: 4},
+		{Size: 9, Count: 9},
+		{Size: 25, Count: 25},
+		{Size: 64, Count: 64},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.Size), func(t *testing.T) {
+			b := fullBitset(tc.Size)
+			if got := b.count(); got != tc.Count {
+				t.Errorf("expected %d, got %d", tc.Count, got)
+			}
+			if !b.has(tc.Size) || b.has(tc.Size+1) {
+				t.Errorf("expected bitset to end at %d", tc.Size)
+			}
+		})
+	}
+}
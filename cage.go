@@ -0,0 +1,104 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Cage is a killer sudoku constraint requiring the values of its cells to add up to Sum,
+// with no value repeated within the cage.
+//
+// Candidates are pruned using the sums that can still be made from the values left in the cage,
+// so a cage of two cells with a sum of 3 only allows 1 and 2.
+type Cage struct {
+	Sum     int
+	Indexes []int
+}
+
+// Cells returns the indexes of the cells in the cage.
+func (c *Cage) Cells() []int {
+	return c.Indexes
+}
+
+// Allowed returns true if the value is not already used in the cage and the remaining empty cells
+// can still be filled with unused values to reach the sum of the cage.
+func (c *Cage) Allowed(values Values, index int, value int) bool {
+	var used bitset
+	sum := value
+	empty := 0
+	for _, i := range c.Indexes {
+		if i == index {
+			continue
+		}
+		v := values.Value(i)
+		if v == 0 {
+			empty++
+			continue
+		}
+		if v == value {
+			return false
+		}
+		used = used.with(v)
+		sum += v
+	}
+	available := fullBitset(values.Size()) &^ used.with(value)
+	return canMakeSum(available, empty, c.Sum-sum)
+}
+
+// String returns a description of the cage.
+func (c *Cage) String() string {
+	return fmt.Sprintf("cage of %d %v", c.Sum, c.Indexes)
+}
+
+// validate returns an error if the cage can never be satisfied in a puzzle of the given size.
+func (c *Cage) validate(puzzleSize int) error {
+	seen := make(map[int]bool, len(c.Indexes))
+	for _, index := range c.Indexes {
+		if seen[index] {
+			return fmt.Errorf("%w: %v contains cell %d more than once", ErrInvalidConstraint, c, index)
+		}
+		seen[index] = true
+	}
+	if !canMakeSum(fullBitset(puzzleSize), len(c.Indexes), c.Sum) {
+		return fmt.Errorf("%w: %v cannot add up to %d using different values between 1 and %d",
+			ErrInvalidConstraint, c, c.Sum, puzzleSize)
+	}
+	return nil
+}
+
+// canMakeSum returns true if count different values from the given set add up to sum.
+func canMakeSum(available bitset, count int, sum int) bool {
+	if count == 0 {
+		return sum == 0
+	}
+	if count > available.count() {
+		return false
+	}
+
+	// check the sum is between the smallest and largest totals that can be made before trying combinations.
+	min, max := 0, 0
+	low, high := available, available
+	for i := 0; i < count; i++ {
+		lowest := low.lowest()
+		min += lowest
+		low = low.without(lowest)
+		highest := high.highest()
+		max += highest
+		high = high.without(highest)
+	}
+	if sum < min || sum > max {
+		return false
+	}
+	if min == sum || max == sum {
+		return true
+	}
+
+	// try each value as the largest in the combination.
+	for rest := available; rest != 0; {
+		highest := rest.highest()
+		rest = rest.without(highest)
+		if highest <= sum && canMakeSum(rest, count-1, sum-highest) {
+			return true
+		}
+	}
+	return false
+}
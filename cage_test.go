@@ -0,0 +1,219 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// killerCages is a killer sudoku with no givens, where the solution is the same as TestPuzzle_Solve_Diagonals.
+var killerCages = []*Cage{
+	{Sum: 13, Indexes: []int{0, 9}},
+	{Sum: 16, Indexes: []int{1, 2, 10, 11}},
+	{Sum: 15, Indexes: []int{3, 4, 13}},
+	{Sum: 2, Indexes: []int{5}},
+	{Sum: 20, Indexes: []int{6, 15, 16}},
+	{Sum: 7, Indexes: []int{7}},
+	{Sum: 13, Indexes: []int{8, 17, 26}},
+	{Sum: 1, Indexes: []int{12}},
+	{Sum: 12, Indexes: []int{14, 23}},
+	{Sum: 15, Indexes: []int{18, 19}},
+	{Sum: 14, Indexes: []int{20, 21, 29}},
+	{Sum: 8, Indexes: []int{22, 31}},
+	{Sum: 5, Indexes: []int{24, 25}},
+	{Sum: 10, Indexes: []int{27, 28, 36}},
+	{Sum: 6, Indexes: []int{30}},
+	{Sum: 21, Indexes: []int{32, 33, 42}},
+	{Sum: 10, Indexes: []int{34, 43}},
+	{Sum: 15, Indexes: []int{35, 44, 53}},
+	{Sum: 21, Indexes: []int{37, 38, 46}},
+	{Sum: 17, Indexes: []int{39, 40, 41, 49}},
+	{Sum: 1, Indexes: []int{45}},
+	{Sum: 12, Indexes: []int{47, 56}},
+	{Sum: 7, Indexes: []int{48}},
+	{Sum: 22, Indexes: []int{50, 58, 59, 67}},
+	{Sum: 15, Indexes: []int{51, 52, 60}},
+	{Sum: 7, Indexes: []int{54, 55}},
+	{Sum: 18, Indexes: []int{57, 66, 75, 76}},
+	{Sum: 16, Indexes: []int{61, 69, 70}},
+	{Sum: 5, Indexes: []int{62}},
+	{Sum: 13, Indexes: []int{63, 72}},
+	{Sum: 22, Indexes: []int{64, 65, 73, 74}},
+	{Sum: 10, Indexes: []int{68, 77, 78}},
+	{Sum: 16, Indexes: []int{71, 79, 80}},
+}
+
+func TestCanMakeSum(t *testing.T) {
+	tests := []struct {
+		Available bitset
+		Count     int
+		Sum       int
+		Exp       bool
+	}{
+		{Available: fullBitset(9), Count: 0, Sum: 0, Exp: true},
+		{Available: fullBitset(9), Count: 0, Sum: 1, Exp: false},
+		{Available: fullBitset(9), Count: 1, Sum: 9, Exp: true},
+		{Available: fullBitset(9), Count: 1, Sum: 10, Exp: false},
+		{Available: fullBitset(9), Count: 2, Sum: 3, Exp: true},
+		{Available: fullBitset(9), Count: 2, Sum: 2, Exp: false},
+		{Available: fullBitset(9), Count: 2, Sum: 17, Exp: true},
+		{Available: fullBitset(9), Count: 2, Sum: 18, Exp: false},
+		{Available: fullBitset(9), Count: 9, Sum: 45, Exp: true},
+		{Available: fullBitset(9), Count: 10, Sum: 45, Exp: false},
+		// 1 + 8 and 2 + 7 are not available, so 9 can only be made from 3 + 6 or 4 + 5.
+		{Available: bitset(0).with(1).with(2).with(3).with(5), Count: 2, Sum: 9, Exp: false},
+		{Available: bitset(0).with(1).with(2).with(3).with(6), Count: 2, Sum: 9, Exp: true},
+		// the sum is between the smallest and largest totals but can't be made.
+		{Available: bitset(0).with(1).with(5).with(9), Count: 2, Sum: 11, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%b/%d/%d", tc.Available, tc.Count, tc.Sum), func(t *testing.T) {
+			if got := canMakeSum(tc.Available, tc.Count, tc.Sum); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestCage_Allowed(t *testing.T) {
+	values := valueSlice{
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	allowed := func(c *Cage, index int) []int {
+		var res []int
+		for value := 1; value <= 9; value++ {
+			if c.Allowed(values, index, value) {
+				res = append(res, value)
+			}
+		}
+		return res
+	}
+
+	tests := []struct {
+		Name  string
+		Cage  *Cage
+		Index int
+		Set   map[int]int
+		Exp   []int
+	}{
+		{Name: "TwoCellsSum3", Cage: &Cage{Sum: 3, Indexes: []int{0, 1}}, Index: 0, Exp: []int{1, 2}},
+		{Name: "TwoCellsSum17", Cage: &Cage{Sum: 17, Indexes: []int{0, 1}}, Index: 0, Exp: []int{8, 9}},
+		{Name: "ThreeCellsSum6", Cage: &Cage{Sum: 6, Indexes: []int{0, 1, 2}}, Index: 0, Exp: []int{1, 2, 3}},
+		{Name: "LastCell", Cage: &Cage{Sum: 10, Indexes: []int{0, 1, 2}}, Index: 2, Set: map[int]int{0: 2, 1: 5}, Exp: []int{3}},
+		{Name: "NoRepeats", Cage: &Cage{Sum: 10, Indexes: []int{0, 1}}, Index: 1, Set: map[int]int{0: 5}, Exp: nil},
+		{Name: "OwnValueIgnored", Cage: &Cage{Sum: 4, Indexes: []int{0, 1}}, Index: 0, Set: map[int]int{0: 2, 1: 3}, Exp: []int{1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			for index, value := range tc.Set {
+				values[index] = value
+			}
+			defer func() {
+				for index := range tc.Set {
+					values[index] = 0
+				}
+			}()
+			if got := allowed(tc.Cage, tc.Index); !reflect.DeepEqual(tc.Exp, got) {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_InvalidCage(t *testing.T) {
+	tests := []struct {
+		Name string
+		Cage *Cage
+	}{
+		{Name: "SumTooLow", Cage: &Cage{Sum: 2, Indexes: []int{0, 1}}},
+		{Name: "SumTooHigh", Cage: &Cage{Sum: 8, Indexes: []int{0, 1}}},
+		{Name: "TooManyCells", Cage: &Cage{Sum: 10, Indexes: []int{0, 1, 2, 3, 4}}},
+		{Name: "RepeatedCell", Cage: &Cage{Sum: 3, Indexes: []int{0, 0}}},
+		{Name: "OutOfRange", Cage: &Cage{Sum: 3, Indexes: []int{0, 16}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(make([]int, 16), WithCages(tc.Cage))
+			if !errors.Is(err, ErrInvalidConstraint) {
+				t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_CageConflict(t *testing.T) {
+	cage := &Cage{Sum: 5, Indexes: []int{0, 1}}
+	_, err := NewPuzzle([]int{
+		1, 2, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	}, WithCages(cage))
+	var got *ConstraintError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConstraintError, got %v", err)
+		return
+	}
+	if got.Index != 0 || got.Value != 1 || got.Constraint != cage {
+		t.Errorf("unexpected error: %+v", got)
+	}
+}
+
+func TestPuzzle_Solve_Killer(t *testing.T) {
+	exp := []int{
+		9, 3, 6, 8, 4, 2, 5, 7, 1,
+		4, 2, 5, 1, 3, 7, 9, 6, 8,
+		7, 8, 1, 9, 6, 5, 2, 3, 4,
+		3, 5, 4, 6, 2, 9, 8, 1, 7,
+		2, 7, 8, 3, 5, 1, 4, 9, 6,
+		1, 6, 9, 7, 8, 4, 3, 5, 2,
+		6, 1, 3, 4, 9, 8, 7, 2, 5,
+		5, 4, 7, 2, 1, 3, 6, 8, 9,
+		8, 9, 2, 5, 7, 6, 1, 4, 3,
+	}
+
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(make([]int, 81), append(config.Options, WithCages(killerCages...))...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+		})
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		p, err := NewPuzzle(make([]int, 81), WithCages(killerCages...), WithCellOrder(MinimumRemainingValuesCellOrder))
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, err := p.HasUniqueSolution(); !unique {
+			t.Errorf("expected puzzle to be unique, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoLogicalStep is returned when none of the logical solving techniques can make progress,
+// so the puzzle can only be solved further by guessing.
+var ErrNoLogicalStep = errors.New("no logical step")
+
+// Candidates is a grid holding the values of a puzzle along with the values each empty cell could still hold,
+// as used by a person solving the puzzle with pencil marks.
+//
+// Candidates are removed by the steps of the logical solver, which apply named techniques such as
+// naked pairs and X-Wings rather than guessing. Every region of the puzzle is used by the techniques,
+// while other constraints only remove candidates as values are placed.
+type Candidates struct {
+	layout *layout
+	// regions holds every region of the puzzle, and cellRegions the indexes of the regions each cell belongs to.
+	regions     []*Region
+	cellRegions [][]int
+	// cellConstraints holds the constraints covering each cell that are not regions.
+	cellConstraints [][]Constraint
+	// square is true if the puzzle is a single square grid, which fish and unique rectangles rely on
+	// to find the cells of a row and column from their positions.
+	square bool
+	// uniqueRectangles is true if the puzzle is a single square grid with only rows, columns and sections,
+	// which unique rectangles rely on.
+	uniqueRectangles bool
+
+	values     []int
+	candidates []bitset
+}
+
+// ErrGivenChanged is returned when values entered into a puzzle give a different value to a cell holding a given.
+var ErrGivenChanged = errors.New("given value changed")
+
+// Candidates returns the candidates of every empty cell in the puzzle, given only the values it was created with.
+// The candidates do not depend on any solve, and changing them does not affect the puzzle.
+func (p *Puzzle) Candidates() *Candidates {
+	return newCandidates(newGrid(p.givens, p.layout))
+}
+
+// CandidatesWith returns the candidates of every empty cell in the puzzle, given the values it was created with
+// along with the values in items, such as those entered by a person solving the puzzle.
+// Items holds a value for every cell of the puzzle, with 0 for empty cells, and cells holding a given
+// may be either 0 or the given value. If items is nil only the givens are used.
+//
+// An error is returned if the values cannot be entered into the puzzle: ErrInvalidPuzzleSize if there are the wrong
+// number of items, ErrGivenChanged if a given is changed, and the same errors as NewPuzzle if a value is out of
+// range or breaks a region or other constraint.
+func (p *Puzzle) CandidatesWith(items []int) (*Candidates, error) {
+	if items == nil {
+		return p.Candidates(), nil
+	}
+	if len(items) != len(p.givens) {
+		return nil, fmt.Errorf("%w: expected %d items, got %d", ErrInvalidPuzzleSize, len(p.givens), len(items))
+	}
+	values := append([]int(nil), items...)
+	for index, given := range p.givens {
+		if given == 0 {
+			continue
+		}
+		if values[index] != 0 && values[index] != given {
+			position := p.layout.positions[index]
+			return nil, fmt.Errorf("%w: cell at row %d, column %d is given as %d, got %d",
+				ErrGivenChanged, position.Row, position.Column, given, values[index])
+		}
+		values[index] = given
+	}
+	if err := validateValues(values, p.layout); err != nil {
+		return nil, err
+	}
+	g := newGrid(values, p.layout)
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return newCandidates(g), nil
+}
+
+// newCandidates returns the candidates of every empty cell in the given grid.
+func newCandidates(g *grid) *Candidates {
+	c := &Candidates{
+		layout:          g.layout,
+		regions:         g.regions,
+		cellRegions:     g.cellRegions,
+		cellConstraints: g.cellConstraints,
+		square:          g.layout.square(),
+		values:          g.items(),
+		candidates:      make([]bitset, len(g.cells)),
+	}
+	c.uniqueRectangles = c.square && len(g.constraints) == 0 && len(g.allowedValues) == 0 &&
+		len(g.regions) == len(g.rows)+len(g.columns)+len(g.sections)
+	for _, cell := range g.cells {
+		if cell.value == 0 {
+			c.candidates[cell.index] = g.candidates(cell)
+		}
+	}
+	return c
+}
+
+// Size returns the puzzle size.
+func (c *Candidates) Size() int {
+	return c.layout.puzzleSize
+}
+
+// Value returns the value of the cell at the given index, or 0 if the cell is empty.
+func (c *Candidates) Value(index int) int {
+	return c.values[index]
+}
+
+// Cell returns the candidates of the cell at the given index in ascending order, or nil if the cell has a value.
+func (c *Candidates) Cell(index int) []int {
+	var res []int
+	for value := 1; value <= c.Size(); value++ {
+		if c.candidates[index].has(value) {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+// Has returns true if value is a candidate of the cell at the given index.
+func (c *Candidates) Has(index int, value int) bool {
+	return c.candidates[index].has(value)
+}
+
+// Items returns the values of every cell, with 0 for empty cells.
+func (c *Candidates) Items() []int {
+	return append([]int(nil), c.values...)
+}
+
+// Solved returns true if every cell has a value.
+func (c *Candidates) Solved() bool {
+	for _, value := range c.values {
+		if value == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Next returns the next step of the logical solver without applying it.
+// Techniques are tried from easiest to hardest, so the step uses the easiest technique that makes progress.
+// ErrNoLogicalStep is returned if no technique makes progress, and an error wrapping ErrMissingIteration
+// if the candidates show the puzzle has no solution.
+func (c *Candidates) Next() (*Step, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	for _, find := range finders {
+		if s := find(c); s != nil {
+			return s, nil
+		}
+	}
+	return nil, ErrNoLogicalStep
+}
+
+// Apply places the values and removes the candidates of the given step.
+// Placing a value removes it from the candidates of every cell sharing a region with it,
+// along with any candidates the other constraints covering the cell no longer allow.
+func (c *Candidates) Apply(s *Step) {
+	for _, e := range s.Eliminations {
+		c.candidates[e.Index] = c.candidates[e.Index].without(e.Value)
+	}
+	for _, p := range s.Placements {
+		c.place(p.Index, p.Value)
+	}
+}
+
+// Solve applies logical steps until every cell has a value, returning the steps in the order they were applied.
+// If the solver gets stuck the steps applied so far are returned along with ErrNoLogicalStep,
+// and the candidates are left partially solved.
+func (c *Candidates) Solve() ([]*Step, error) {
+	var steps []*Step
+	for !c.Solved() {
+		s, err := c.Next()
+		if err != nil {
+			return steps, err
+		}
+		c.Apply(s)
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+// place sets the value of the cell at index and removes the candidates it rules out.
+func (c *Candidates) place(index int, value int) {
+	c.values[index] = value
+	c.candidates[index] = 0
+	for _, peer := range c.peers(index) {
+		c.candidates[peer] = c.candidates[peer].without(value)
+	}
+	for _, constraint := range c.cellConstraints[index] {
+		for _, other := range constraint.Cells() {
+			if c.values[other] != 0 {
+				continue
+			}
+			for v := 1; v <= c.Size(); v++ {
+				if c.candidates[other].has(v) && !constraint.Allowed(c, other, v) {
+					c.candidates[other] = c.candidates[other].without(v)
+				}
+			}
+		}
+	}
+}
+
+// check returns an error wrapping ErrMissingIteration if an empty cell has no candidates,
+// or a value missing from a region that covers every value has nowhere to go.
+func (c *Candidates) check() error {
+	for index, value := range c.values {
+		if value == 0 && c.candidates[index] == 0 {
+			return fmt.Errorf("%w: cell %d has no candidates", ErrMissingIteration, index)
+		}
+	}
+	for _, r := range c.fullRegions() {
+		var placed, possible bitset
+		for _, index := range r.Indexes {
+			placed = placed.with(c.values[index])
+			possible |= c.candidates[index]
+		}
+		if missing := fullBitset(c.Size()) &^ placed &^ possible; missing != 0 {
+			return fmt.Errorf("%w: %v has nowhere to place %d", ErrMissingIteration, r, missing.lowest())
+		}
+	}
+	return nil
+}
+
+// mostConstrainedCell returns the index of the empty cell with the fewest candidates, or -1 if every cell has a value.
+// If multiple cells have the same number of candidates the one with the lowest index is returned.
+func (c *Candidates) mostConstrainedCell() int {
+	best, bestCount := -1, 0
+	for index, value := range c.values {
+		if value != 0 {
+			continue
+		}
+		if count := c.candidates[index].count(); best == -1 || count < bestCount {
+			best, bestCount = index, count
+		}
+	}
+	return best
+}
+
+// fullRegions returns the regions that must contain every value.
+func (c *Candidates) fullRegions() []*Region {
+	var res []*Region
+	for _, r := range c.regions {
+		if len(r.Indexes) == c.Size() {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// sees returns true if the cells at a and b are different cells that share a region, so cannot hold the same value.
+func (c *Candidates) sees(a int, b int) bool {
+	if a == b {
+		return false
+	}
+	for _, ra := range c.cellRegions[a] {
+		for _, rb := range c.cellRegions[b] {
+			if ra == rb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// peers returns the indexes of every other cell sharing a region with the cell at index.
+func (c *Candidates) peers(index int) []int {
+	seen := map[int]bool{index: true}
+	var res []int
+	for _, r := range c.cellRegions[index] {
+		for _, other := range c.regions[r].Indexes {
+			if !seen[other] {
+				seen[other] = true
+				res = append(res, other)
+			}
+		}
+	}
+	return res
+}
+
+// cellsWith returns the indexes of the cells of the given region that have value as a candidate.
+func (c *Candidates) cellsWith(r *Region, value int) []int {
+	var res []int
+	for _, index := range r.Indexes {
+		if c.candidates[index].has(value) {
+			res = append(res, index)
+		}
+	}
+	return res
+}
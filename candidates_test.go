@@ -0,0 +1,336 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPuzzle_Candidates(t *testing.T) {
+	in := []int{
+		1, 0, 0, 0,
+		0, 0, 3, 0,
+		0, 4, 0, 0,
+		0, 0, 0, 2,
+	}
+	p, err := NewPuzzle(in)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c := p.Candidates()
+	if got := c.Size(); got != 4 {
+		t.Errorf("expected size 4, got %d", got)
+	}
+	if got := c.Value(6); got != 3 {
+		t.Errorf("expected value 3, got %d", got)
+	}
+	if got := c.Cell(0); got != nil {
+		t.Errorf("expected no candidates for a cell with a value, got %v", got)
+	}
+	if exp, got := []int{2, 3}, c.Cell(1); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if !c.Has(1, 3) || c.Has(1, 4) {
+		t.Errorf("expected cell 1 to have 3 but not 4 as a candidate")
+	}
+	if got := c.Items(); !reflect.DeepEqual(in, got) {
+		t.Errorf("expected %v, got %v", in, got)
+	}
+	if c.Solved() {
+		t.Errorf("expected the candidates not to be solved")
+	}
+}
+
+func TestPuzzle_CandidatesWith(t *testing.T) {
+	p, err := NewPuzzle([]int{
+		1, 0, 0, 0,
+		0, 0, 3, 0,
+		0, 4, 0, 0,
+		0, 0, 0, 2,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c, err := p.CandidatesWith([]int{
+		0, 2, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	// the givens are kept even though they were left out of the items.
+	if exp, got := []int{
+		1, 2, 0, 0,
+		0, 0, 3, 0,
+		0, 4, 0, 0,
+		0, 0, 0, 2,
+	}, c.Items(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	// the entered 2 and given 1 share its row, and the given 3 its column.
+	if exp, got := []int{4}, c.Cell(2); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if c, err := p.CandidatesWith(nil); err != nil || !reflect.DeepEqual(p.Candidates(), c) {
+		t.Errorf("expected the candidates of the givens, got %v, %v", c, err)
+	}
+}
+
+func TestPuzzle_CandidatesWith_Invalid(t *testing.T) {
+	p, err := NewPuzzle([]int{
+		1, 0, 0, 0,
+		0, 0, 3, 0,
+		0, 4, 0, 0,
+		0, 0, 0, 2,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	tests := []struct {
+		Name  string
+		Items []int
+		Err   error
+	}{
+		{Name: "Size", Items: make([]int, 9), Err: ErrInvalidPuzzleSize},
+		{Name: "GivenChanged", Items: []int{
+			2, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+		}, Err: ErrGivenChanged},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := p.CandidatesWith(tc.Items); !errors.Is(err, tc.Err) {
+				t.Errorf("expected error %v, got %v", tc.Err, err)
+			}
+		})
+	}
+
+	var invalidValue *InvalidValueError
+	if _, err := p.CandidatesWith([]int{
+		0, 5, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	}); !errors.As(err, &invalidValue) {
+		t.Errorf("expected an *InvalidValueError, got %v", err)
+	}
+	var conflict *ConflictError
+	if _, err := p.CandidatesWith([]int{
+		0, 0, 0, 0,
+		0, 0, 0, 3,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	}); !errors.As(err, &conflict) {
+		t.Errorf("expected a *ConflictError, got %v", err)
+	}
+}
+
+func TestCandidates_Apply(t *testing.T) {
+	p, err := NewPuzzle(make([]int, 16))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c := p.Candidates()
+	c.Apply(&Step{
+		Placements:   []Candidate{{Index: 0, Value: 1}},
+		Eliminations: []Candidate{{Index: 15, Value: 4}},
+	})
+	tests := []struct {
+		Index int
+		Exp   []int
+	}{
+		{Index: 0, Exp: nil},
+		// row, column and section.
+		{Index: 3, Exp: []int{2, 3, 4}},
+		{Index: 12, Exp: []int{2, 3, 4}},
+		{Index: 5, Exp: []int{2, 3, 4}},
+		{Index: 10, Exp: []int{1, 2, 3, 4}},
+		{Index: 15, Exp: []int{1, 2, 3}},
+	}
+	for _, tc := range tests {
+		if got := c.Cell(tc.Index); !reflect.DeepEqual(tc.Exp, got) {
+			t.Errorf("expected cell %d to have candidates %v, got %v", tc.Index, tc.Exp, got)
+		}
+	}
+	if got := c.Value(0); got != 1 {
+		t.Errorf("expected value 1, got %d", got)
+	}
+	if got := p.Candidates().Value(0); got != 0 {
+		t.Errorf("expected the puzzle to be unchanged, got value %d", got)
+	}
+}
+
+func TestCandidates_Apply_Constraints(t *testing.T) {
+	// cells 0 and 5 must add up to 3, so placing 1 in one leaves 2 in the other.
+	p, err := NewPuzzle(make([]int, 16), WithCages(&Cage{Sum: 3, Indexes: []int{0, 5}}))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c := p.Candidates()
+	if exp, got := []int{1, 2}, c.Cell(5); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	c.Apply(&Step{Placements: []Candidate{{Index: 0, Value: 1}}})
+	if exp, got := []int{2}, c.Cell(5); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestCandidates_Next_HiddenSingle(t *testing.T) {
+	// the 1 in the second column rules out the second cell of the first row,
+	// and the 1 in the second section rules out its last two cells.
+	p, err := NewPuzzle([]int{
+		0, 0, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 0,
+		0, 1, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c := p.Candidates()
+	got, err := c.Next()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	exp := &Step{
+		Technique:  HiddenSingle,
+		Placements: []Candidate{{Index: 0, Value: 1}},
+		Reasons:    []int{13, 6},
+		Regions:    []*Region{c.layout.rows[0]},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestCandidates_Solve(t *testing.T) {
+	tests := []struct {
+		Name    string
+		In      []int
+		Options []Option
+	}{
+		{Name: "Sudoku", In: []int{
+			0, 0, 3, 0, 2, 0, 6, 0, 0,
+			9, 0, 0, 3, 0, 5, 0, 0, 1,
+			0, 0, 1, 8, 0, 6, 4, 0, 0,
+			0, 0, 8, 1, 0, 2, 9, 0, 0,
+			7, 0, 0, 0, 0, 0, 0, 0, 8,
+			0, 0, 6, 7, 0, 8, 2, 0, 0,
+			0, 0, 2, 6, 0, 9, 5, 0, 0,
+			8, 0, 0, 2, 0, 3, 0, 0, 9,
+			0, 0, 5, 0, 1, 0, 3, 0, 0,
+		}},
+		{Name: "16x16", In: sixteenPuzzle},
+		{Name: "LatinSquare", In: []int{
+			0, 6, 0, 1, 2, 0, 0,
+			3, 0, 5, 0, 4, 0, 0,
+			0, 5, 0, 0, 0, 0, 0,
+			2, 1, 0, 0, 0, 5, 0,
+			0, 0, 0, 0, 7, 0, 4,
+			0, 0, 0, 0, 0, 7, 0,
+			7, 2, 0, 0, 6, 0, 1,
+		}, Options: []Option{WithLatinSquare()}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			exp := solve(t, tc.In, tc.Options...)
+			p, err := NewPuzzle(tc.In, tc.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			c := p.Candidates()
+			steps, err := c.Solve()
+			if err != nil {
+				t.Errorf("could not solve puzzle logically: %s", err)
+				return
+			}
+			for _, s := range steps {
+				checkStep(t, s, exp)
+			}
+			if !c.Solved() {
+				t.Errorf("expected the candidates to be solved")
+			}
+			if got := c.Items(); !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+		})
+	}
+}
+
+func TestCandidates_Solve_NoLogicalStep(t *testing.T) {
+	exp := solve(t, hardPuzzle)
+	p, err := NewPuzzle(hardPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c := p.Candidates()
+	steps, err := c.Solve()
+	if !errors.Is(err, ErrNoLogicalStep) {
+		t.Errorf("expected error %v, got %v", ErrNoLogicalStep, err)
+	}
+	for _, s := range steps {
+		checkStep(t, s, exp)
+	}
+	if c.Solved() {
+		t.Errorf("expected the candidates not to be solved")
+	}
+}
+
+func TestCandidates_Next_NoSolution(t *testing.T) {
+	// the third cell of the first row can't hold 3 or 4, which are already in its column.
+	p, err := NewPuzzle([]int{
+		1, 2, 0, 0,
+		0, 0, 3, 0,
+		0, 0, 4, 0,
+		0, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if _, err := p.Candidates().Next(); !errors.Is(err, ErrMissingIteration) {
+		t.Errorf("expected error %v, got %v", ErrMissingIteration, err)
+	}
+}
+
+func TestCandidates_UniqueRectangles(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Options []Option
+		Exp     bool
+	}{
+		{Name: "Sudoku", Exp: true},
+		{Name: "LatinSquare", Options: []Option{WithLatinSquare()}, Exp: true},
+		{Name: "Diagonals", Options: []Option{WithDiagonals()}, Exp: false},
+		{Name: "Cages", Options: []Option{WithCages(&Cage{Sum: 3, Indexes: []int{0, 1}})}, Exp: false},
+		{Name: "Odd", Options: []Option{WithOdd(0)}, Exp: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			p, err := NewPuzzle(make([]int, 81), tc.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if got := p.Candidates().uniqueRectangles; got != tc.Exp {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
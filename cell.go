@@ -10,15 +10,6 @@ type cell struct {
 // group is a group of cells.
 type group []*cell
 
-// iterate returns a iterate of the cell.
-func (c *cell) copy() *cell {
-	return &cell{
-		fixed: c.fixed,
-		value: c.value,
-		index: c.index,
-	}
-}
-
 // getRowFromIndex returns the row index for the given cell index.
 func getRowFromIndex(index int, puzzleSize int) int {
 	return index / puzzleSize
@@ -47,3 +38,11 @@ func getSectionFromIndex(index int, puzzleSize int, sectionSize int) int {
 	column := getSectionColumnFromIndex(index, puzzleSize, sectionSize)
 	return (row * sectionSize) + column
 }
+
+// getRectangularSectionFromIndex returns the index for the section from the given cell index,
+// where each section is sectionWidth cells wide and sectionHeight cells tall.
+func getRectangularSectionFromIndex(index int, puzzleSize int, sectionWidth int, sectionHeight int) int {
+	row := getRowFromIndex(getRowFromIndex(index, puzzleSize), sectionHeight)
+	column := getRowFromIndex(getColumnFromIndex(index, puzzleSize), sectionWidth)
+	return (row * (puzzleSize / sectionWidth)) + column
+}
@@ -222,3 +222,42 @@ func TestGetSectionFromIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRectangularSectionFromIndex(t *testing.T) {
+	tests := []struct {
+		Index         int
+		PuzzleSize    int
+		SectionWidth  int
+		SectionHeight int
+		Out           int
+	}{
+		// 6x6 with sections 3 wide and 2 tall.
+		{Index: 0, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 0},
+		{Index: 2, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 0},
+		{Index: 3, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 1},
+		{Index: 8, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 0},
+		{Index: 11, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 1},
+		{Index: 12, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 2},
+		{Index: 35, PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2, Out: 5},
+		// 6x6 with sections 2 wide and 3 tall.
+		{Index: 2, PuzzleSize: 6, SectionWidth: 2, SectionHeight: 3, Out: 1},
+		{Index: 5, PuzzleSize: 6, SectionWidth: 2, SectionHeight: 3, Out: 2},
+		{Index: 17, PuzzleSize: 6, SectionWidth: 2, SectionHeight: 3, Out: 2},
+		{Index: 18, PuzzleSize: 6, SectionWidth: 2, SectionHeight: 3, Out: 3},
+		// 12x12 with sections 4 wide and 3 tall.
+		{Index: 143, PuzzleSize: 12, SectionWidth: 4, SectionHeight: 3, Out: 11},
+		{Index: 40, PuzzleSize: 12, SectionWidth: 4, SectionHeight: 3, Out: 4},
+		// square sections should match getSectionFromIndex.
+		{Index: 60, PuzzleSize: 9, SectionWidth: 3, SectionHeight: 3, Out: 8},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d", tc.Index), func(t *testing.T) {
+			exp := tc.Out
+			got := getRectangularSectionFromIndex(tc.Index, tc.PuzzleSize, tc.SectionWidth, tc.SectionHeight)
+			if exp != got {
+				t.Errorf("expected %d, got %d", exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"github.com/tomwright/sudoku"
+	"strconv"
+	"strings"
+)
+
+// directive is a line in a puzzle file that describes an extra constraint, such as a killer cage.
+// Directives come after the puzzle values, one per line, starting with the name of the directive:
+//
+//	cage 15 0 1 9
+type directive struct {
+	name string
+	args []string
+	// line is the line number of the directive within the file.
+	line int
+}
+
+// directiveParsers contains a parser for every directive name, which returns the option the directive describes.
+var directiveParsers = map[string]func(args []string) (sudoku.Option, error){
+	"cage":         parseCage,
+	"region":       parseRegion,
+	"greater":      edgeParser(sudoku.GreaterThan),
+	"white":        edgeParser(sudoku.Consecutive),
+	"black":        edgeParser(sudoku.Double),
+	"thermo":       parseThermometer,
+	"arrow":        parseArrow,
+	"sandwich":     outsideClueParser(sudoku.WithSandwiches),
+	"skyscraper":   outsideClueParser(sudoku.WithSkyscrapers),
+	"littlekiller": parseLittleKiller,
+	"odd":          indexesParser(sudoku.WithOdd),
+	"even":         indexesParser(sudoku.WithEven),
+	"allowed":      parseAllowedValues,
+}
+
+// parseDirectives returns the options described by the given directives.
+func parseDirectives(directives []directive) ([]sudoku.Option, error) {
+	opts := make([]sudoku.Option, 0, len(directives))
+	for _, d := range directives {
+		parse, ok := directiveParsers[d.name]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown directive %q", d.line, d.name)
+		}
+		opt, err := parse(d.args)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid %s: %w", d.line, d.name, err)
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
+// parseInts parses every arg as an integer.
+func parseInts(args []string) ([]int, error) {
+	res := make([]int, len(args))
+	for i, arg := range args {
+		parsed, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", arg)
+		}
+		res[i] = parsed
+	}
+	return res, nil
+}
+
+// parseCage parses a killer cage in the form: cage SUM INDEX INDEX...
+func parseCage(args []string) (sudoku.Option, error) {
+	values, err := parseInts(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < 2 {
+		return nil, fmt.Errorf("expected a sum followed by at least one cell index")
+	}
+	return sudoku.WithCages(&sudoku.Cage{Sum: values[0], Indexes: values[1:]}), nil
+}
+
+// parseRegion parses an extra region, in which no value can be repeated, in the form: region INDEX INDEX...
+func parseRegion(args []string) (sudoku.Option, error) {
+	indexes, err := parseInts(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("expected at least one cell index")
+	}
+	return sudoku.WithRegions(&sudoku.Region{Kind: "region", Indexes: indexes}), nil
+}
+
+// edgeParser returns a parser for an edge with the given relation between two cells, in the form: NAME INDEX INDEX
+func edgeParser(relation sudoku.Relation) func(args []string) (sudoku.Option, error) {
+	return func(args []string) (sudoku.Option, error) {
+		indexes, err := parseInts(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(indexes) != 2 {
+			return nil, fmt.Errorf("expected 2 cell indexes, got %d", len(indexes))
+		}
+		return sudoku.WithEdges(&sudoku.Edge{Relation: relation, A: indexes[0], B: indexes[1]}), nil
+	}
+}
+
+// parseThermometer parses a thermometer, starting from the bulb, in the form: thermo INDEX INDEX...
+func parseThermometer(args []string) (sudoku.Option, error) {
+	indexes, err := parseInts(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) < 2 {
+		return nil, fmt.Errorf("expected at least 2 cell indexes")
+	}
+	return sudoku.WithThermometers(&sudoku.Thermometer{Indexes: indexes}), nil
+}
+
+// parseArrow parses an arrow in the form: arrow CIRCLE INDEX INDEX...
+func parseArrow(args []string) (sudoku.Option, error) {
+	indexes, err := parseInts(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) < 2 {
+		return nil, fmt.Errorf("expected the index of the circle followed by at least one cell index")
+	}
+	return sudoku.WithArrows(&sudoku.Arrow{Circle: indexes[0], Indexes: indexes[1:]}), nil
+}
+
+// outsideClueParser returns a parser for a clue outside a row or column, in the form: NAME SIDE LINE VALUE
+// where SIDE is top, bottom, left or right.
+func outsideClueParser(option func(clues ...sudoku.OutsideClue) sudoku.Option) func(args []string) (sudoku.Option, error) {
+	return func(args []string) (sudoku.Option, error) {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("expected a side, line and value")
+		}
+		side, err := parseSide(args[0])
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseInts(args[1:])
+		if err != nil {
+			return nil, err
+		}
+		return option(sudoku.OutsideClue{Side: side, Line: values[0], Value: values[1]}), nil
+	}
+}
+
+func parseSide(side string) (sudoku.Side, error) {
+	for _, s := range []sudoku.Side{sudoku.TopSide, sudoku.BottomSide, sudoku.LeftSide, sudoku.RightSide} {
+		if s.String() == side {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("expected top, bottom, left or right, got %q", side)
+}
+
+// parseLittleKiller parses a little killer clue in the form: littlekiller SUM INDEX INDEX...
+func parseLittleKiller(args []string) (sudoku.Option, error) {
+	values, err := parseInts(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < 2 {
+		return nil, fmt.Errorf("expected a sum followed by at least one cell index")
+	}
+	return sudoku.WithLittleKillers(&sudoku.LittleKiller{Sum: values[0], Indexes: values[1:]}), nil
+}
+
+// indexesParser returns a parser for a list of cells, in the form: NAME INDEX INDEX...
+func indexesParser(option func(indexes ...int) sudoku.Option) func(args []string) (sudoku.Option, error) {
+	return func(args []string) (sudoku.Option, error) {
+		indexes, err := parseInts(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(indexes) == 0 {
+			return nil, fmt.Errorf("expected at least one cell index")
+		}
+		return option(indexes...), nil
+	}
+}
+
+// parseAllowedValues parses the values some cells are restricted to in the form: allowed VALUE,VALUE... INDEX INDEX...
+func parseAllowedValues(args []string) (sudoku.Option, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("expected a list of values followed by at least one cell index")
+	}
+	values, err := parseInts(strings.Split(args[0], ","))
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := parseInts(args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return sudoku.WithAllowedValues(&sudoku.AllowedValues{Kind: "allowed", Indexes: indexes, Values: values}), nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/cheggaaa/pb/v3"
@@ -16,28 +18,159 @@ import (
 // MonitorCompletionRateInterval defines how often we should check the status of the puzzle.
 const MonitorCompletionRateInterval = time.Millisecond * 200
 
+// unusedCell is used in input and output files for cells of a multi-grid canvas that are not in any grid.
+const unusedCell = "."
+
+// solver is implemented by both single and multi-grid puzzles.
+type solver interface {
+	SolveContext(ctx context.Context) error
+	CompletionRate() (*sudoku.CompletionRate, error)
+	HasUniqueSolutionContext(ctx context.Context) (bool, error)
+}
+
 func main() {
+	// sudoku rate ... rates the difficulty of the puzzle instead of solving it.
+	rate := len(os.Args) > 1 && os.Args[1] == "rate"
+	if rate {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+
 	in := flag.String("in", "", "File path to an input file containing the sudoku puzzle to solve")
 	out := flag.String("out", "", "File path where the solved sudoku puzzle will be written")
+	section := flag.String("section", "", "Width and height of each section for puzzles with rectangular sections, e.g. 3x2. Defaults to square sections. Can't be used with -regions")
+	regions := flag.String("regions", "", "File path to a region map for jigsaw puzzles, giving the region ID of every cell in the same layout as the puzzle")
+	latin := flag.Bool("latin", false, "Solve a Latin square with only rows and columns and no sections, allowing puzzles of any size")
+	samurai := flag.Bool("samurai", false, "Solve a Samurai puzzle of five overlapping 9x9 grids, given as a 21x21 canvas with . for cells outside every grid")
+	diagonals := flag.Bool("diagonals", false, "Require both main diagonals to contain every value exactly once, as in Sudoku-X puzzles")
+	windoku := flag.Bool("windoku", false, "Add the four extra window regions of Windoku or Hyper puzzles, which must contain every value exactly once")
+	antiKnight := flag.Bool("antiknight", false, "Prevent cells a chess knight's move apart from holding the same value")
+	antiKing := flag.Bool("antiking", false, "Prevent cells a chess king's move apart from holding the same value")
+	nonConsecutive := flag.Bool("nonconsecutive", false, "Prevent horizontally or vertically adjacent cells from holding consecutive values")
+	algorithm := flag.String("algorithm", sudoku.BacktrackingAlgorithm.String(), "Algorithm used to solve the puzzle: backtracking or dlx")
+	order := flag.String("order", sudoku.LinearCellOrder.String(), "Order in which empty cells are filled: linear or mrv")
+	propagation := flag.Bool("propagate", true, "Fill in naked and hidden singles before the search and after every guess")
+	unique := flag.Bool("unique", false, "Fail if the puzzle does not have exactly one solution")
+	timeout := flag.Duration("timeout", 0, "Maximum amount of time to spend solving the puzzle, including the -unique check, e.g. 30s. Zero means no limit")
 	flag.Parse()
 
 	if in == nil || *in == "" {
 		_, _ = fmt.Fprintf(os.Stderr, "missing required -in argument\n")
 		os.Exit(2)
 	}
-	if out == nil || *out == "" {
+	if !rate && (out == nil || *out == "") {
 		_, _ = fmt.Fprintf(os.Stderr, "missing required -out argument\n")
 		os.Exit(2)
 	}
 
-	input := getInput(*in)
+	input, directives := getInput(*in)
+
+	solveAlgorithm, err := parseAlgorithm(*algorithm)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+	cellOrder, err := parseCellOrder(*order)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(2)
+	}
+
+	opts := []sudoku.Option{
+		sudoku.WithAlgorithm(solveAlgorithm),
+		sudoku.WithCellOrder(cellOrder),
+		sudoku.WithPropagation(*propagation),
+	}
+	if *regions != "" {
+		// the regions replace the sections, so a section size would be ignored.
+		if *section != "" {
+			_, _ = fmt.Fprintf(os.Stderr, "-section can't be used with -regions, which replaces the sections\n")
+			os.Exit(2)
+		}
+		regionMap, regionDirectives := getInput(*regions)
+		if len(regionDirectives) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "bad region map: line %d: only region IDs are allowed, got %s\n",
+				regionDirectives[0].line, regionDirectives[0].name)
+			os.Exit(3)
+		}
+		opts = append(opts, sudoku.WithRegionMap(regionMap))
+	}
+	if *diagonals {
+		opts = append(opts, sudoku.WithDiagonals())
+	}
+	if *latin {
+		opts = append(opts, sudoku.WithLatinSquare())
+	}
+	if *windoku {
+		opts = append(opts, sudoku.WithWindows())
+	}
+	if *antiKnight {
+		opts = append(opts, sudoku.WithAntiKnight())
+	}
+	if *antiKing {
+		opts = append(opts, sudoku.WithAntiKing())
+	}
+	if *nonConsecutive {
+		opts = append(opts, sudoku.WithNonConsecutive())
+	}
+	if *section != "" {
+		width, height, err := parseSectionSize(*section)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(2)
+		}
+		opts = append(opts, sudoku.WithSectionSize(width, height))
+	}
+
+	directiveOpts, err := parseDirectives(directives)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "bad input: %s\n", err)
+		os.Exit(3)
+	}
+	opts = append(opts, directiveOpts...)
 
-	puzzle, err := sudoku.NewPuzzle(input)
+	var puzzle solver
+	if *samurai {
+		puzzle, err = sudoku.NewSamuraiPuzzle(input, opts...)
+	} else {
+		puzzle, err = sudoku.NewPuzzle(input, opts...)
+	}
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "failed to create puzzle instance: %s\n", err)
 		os.Exit(4)
 	}
 
+	if rate {
+		ratePuzzle(puzzle)
+		return
+	}
+
+	// the timeout covers checking the puzzle is unique as well as solving it.
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	if *unique {
+		_, err := puzzle.HasUniqueSolutionContext(ctx)
+		var notUnique *sudoku.NotUniqueError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			_, _ = fmt.Fprintf(os.Stderr, "Timed out checking the puzzle is unique after %s\n", *timeout)
+			os.Exit(6)
+		case errors.Is(err, sudoku.ErrMissingIteration):
+			_, _ = fmt.Fprintf(os.Stderr, "puzzle has no solution\n")
+			os.Exit(7)
+		case errors.As(err, &notUnique):
+			_, _ = fmt.Fprintf(os.Stderr, "puzzle is not unique: %s\n", err)
+			os.Exit(7)
+		case err != nil:
+			_, _ = fmt.Fprintf(os.Stderr, "failed to check the puzzle is unique: %s\n", err)
+			os.Exit(4)
+		}
+	}
+
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
 
@@ -58,7 +191,7 @@ func main() {
 	bar.Start()
 
 	// solve the puzzle in a routine
-	go solvePuzzle(wg, puzzle)
+	go solvePuzzle(ctx, wg, puzzle)
 	// periodically fetch the puzzle completion rate and print it
 	go monitorCompletionRate(wg, puzzle, bar)
 
@@ -76,8 +209,17 @@ func main() {
 
 	switch {
 	case completionRate.Completed:
-		_, _ = fmt.Fprintf(os.Stderr, "Solved puzzle in %s\n", completionRate.CompletedAt.Sub(completionRate.StartedAt))
+		_, _ = fmt.Fprintf(os.Stderr, "Solved puzzle in %s (%d cells deduced, %d cells guessed)\n",
+			completionRate.CompletedAt.Sub(completionRate.StartedAt), completionRate.DeducedCells, completionRate.GuessedCells)
 		break
+	case completionRate.TimedOut:
+		_, _ = fmt.Fprintf(os.Stderr, "Timed out solving puzzle after %s\n", completionRate.CancelledAt.Sub(completionRate.StartedAt))
+		os.Exit(6)
+		return
+	case completionRate.Cancelled:
+		_, _ = fmt.Fprintf(os.Stderr, "Cancelled solving puzzle: %v\n", completionRate.Error)
+		os.Exit(6)
+		return
 	case completionRate.Failed:
 		_, _ = fmt.Fprintf(os.Stderr, "Failed to solve puzzle: %v\n", completionRate.Error)
 		os.Exit(4)
@@ -86,15 +228,54 @@ func main() {
 		panic("unexpected completion rate status")
 	}
 
-	writeOutput(*out, puzzle)
+	lines, err := formatOutput(puzzle)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "cannot get puzzle results: %s\n", err)
+		os.Exit(5)
+	}
+	writeOutput(*out, lines)
 }
 
-func solvePuzzle(wg *sync.WaitGroup, p *sudoku.Puzzle) {
+func parseSectionSize(size string) (int, int, error) {
+	parts := strings.Split(size, "x")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid -section argument: expected WIDTHxHEIGHT, got %s", size)
+	}
+	width, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -section width: %w", err)
+	}
+	height, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid -section height: %w", err)
+	}
+	return width, height, nil
+}
+
+func parseAlgorithm(algorithm string) (sudoku.Algorithm, error) {
+	for _, a := range []sudoku.Algorithm{sudoku.BacktrackingAlgorithm, sudoku.DancingLinksAlgorithm} {
+		if a.String() == algorithm {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid -algorithm argument: %s", algorithm)
+}
+
+func parseCellOrder(order string) (sudoku.CellOrder, error) {
+	for _, o := range []sudoku.CellOrder{sudoku.LinearCellOrder, sudoku.MinimumRemainingValuesCellOrder} {
+		if o.String() == order {
+			return o, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid -order argument: %s", order)
+}
+
+func solvePuzzle(ctx context.Context, wg *sync.WaitGroup, p solver) {
 	defer wg.Done()
-	_ = p.Solve()
+	_ = p.SolveContext(ctx)
 }
 
-func monitorCompletionRate(wg *sync.WaitGroup, p *sudoku.Puzzle, bar *pb.ProgressBar) {
+func monitorCompletionRate(wg *sync.WaitGroup, p solver, bar *pb.ProgressBar) {
 	defer wg.Done()
 	for {
 		completionRate, err := p.CompletionRate()
@@ -110,6 +291,8 @@ func monitorCompletionRate(wg *sync.WaitGroup, p *sudoku.Puzzle, bar *pb.Progres
 			return
 		case completionRate.Failed:
 			return
+		case completionRate.Cancelled:
+			return
 		default:
 			// still in progress.
 			time.Sleep(MonitorCompletionRateInterval)
@@ -117,7 +300,9 @@ func monitorCompletionRate(wg *sync.WaitGroup, p *sudoku.Puzzle, bar *pb.Progres
 	}
 }
 
-func getInput(path string) []int {
+// getInput reads a puzzle file, returning the values of the grid and any directives that follow it.
+// Cells given as . are returned as 0.
+func getInput(path string) ([]int, []directive) {
 	// open input file
 	inFile, err := os.Open(path)
 	if err != nil {
@@ -127,14 +312,29 @@ func getInput(path string) []int {
 	defer inFile.Close()
 
 	input := make([]int, 0)
+	directives := make([]directive, 0)
 
 	// read unsolved puzzle from the file
 	inScanner := bufio.NewScanner(inFile)
+	lineNumber := 0
 	for inScanner.Scan() {
-		split := strings.Split(strings.TrimSpace(inScanner.Text()), " ")
+		lineNumber++
+		split := strings.Fields(inScanner.Text())
+		if len(split) == 0 || strings.HasPrefix(split[0], "#") {
+			continue
+		}
+		if _, err := strconv.Atoi(split[0]); err != nil && split[0] != unusedCell {
+			// lines starting with a word describe extra constraints.
+			directives = append(directives, directive{name: split[0], args: split[1:], line: lineNumber})
+			continue
+		}
+		if len(directives) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "bad input: line %d: puzzle values must come before any directives\n", lineNumber)
+			os.Exit(3)
+		}
 		for _, s := range split {
-			s = strings.TrimSpace(s)
-			if s == "" {
+			if s == unusedCell {
+				input = append(input, 0)
 				continue
 			}
 			parsed, err := strconv.ParseInt(s, 10, 64)
@@ -154,42 +354,76 @@ func getInput(path string) []int {
 		os.Exit(3)
 	}
 
-	return input
+	return input, directives
 }
 
-func writeOutput(path string, p *sudoku.Puzzle) {
-	// open output file
-	outFile, err := os.Create(path)
-	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "cannot create output file: %s\n", err)
-		os.Exit(5)
+// formatOutput returns the lines of the output file for a solved puzzle.
+func formatOutput(p solver) ([]string, error) {
+	switch p := p.(type) {
+	case *sudoku.MultiPuzzle:
+		return formatCanvas(p)
+	case *sudoku.Puzzle:
+		return formatGrid(p)
+	default:
+		return nil, fmt.Errorf("unexpected puzzle type %T", p)
 	}
-	defer outFile.Close()
+}
 
+func formatGrid(p *sudoku.Puzzle) ([]string, error) {
 	results, err := p.Result()
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "cannot get puzzle results: %s\n", err)
-		os.Exit(5)
+		return nil, err
 	}
 
 	formattedResults, err := sudoku.FormatPuzzle(results)
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "cannot format puzzle results: %s\n", err)
-		os.Exit(5)
+		return nil, fmt.Errorf("cannot format puzzle results: %w", err)
 	}
 
-	resultsString := ""
+	lines := make([]string, 0, len(formattedResults))
 	for _, line := range formattedResults {
+		cells := make([]string, len(line))
 		for k, cell := range line {
-			resultsString += fmt.Sprintf("%d", cell)
-			if k != len(line)-1 {
-				resultsString += " "
+			cells[k] = strconv.Itoa(cell)
+		}
+		lines = append(lines, strings.Join(cells, " "))
+	}
+	return lines, nil
+}
+
+// formatCanvas returns the canvas of a multi-grid puzzle, with . for cells outside every grid.
+func formatCanvas(p *sudoku.MultiPuzzle) ([]string, error) {
+	canvas, err := p.Canvas()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0, len(canvas)/p.Width())
+	for row := 0; row < len(canvas); row += p.Width() {
+		cells := make([]string, p.Width())
+		for k := range cells {
+			index := row + k
+			if p.InGrid(index) {
+				cells[k] = strconv.Itoa(canvas[index])
+			} else {
+				cells[k] = unusedCell
 			}
 		}
-		resultsString += "\n"
+		lines = append(lines, strings.Join(cells, " "))
 	}
+	return lines, nil
+}
+
+func writeOutput(path string, lines []string) {
+	// open output file
+	outFile, err := os.Create(path)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "cannot create output file: %s\n", err)
+		os.Exit(5)
+	}
+	defer outFile.Close()
 
-	_, err = outFile.WriteString(resultsString)
+	_, err = outFile.WriteString(strings.Join(lines, "\n") + "\n")
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "could not write results to file: %s\n", err)
 		os.Exit(5)
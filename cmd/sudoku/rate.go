@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"github.com/tomwright/sudoku"
+	"os"
+)
+
+// ratePuzzle prints the difficulty of the given puzzle along with the techniques needed to solve it.
+func ratePuzzle(s solver) {
+	p, ok := s.(*sudoku.Puzzle)
+	if !ok {
+		_, _ = fmt.Fprintf(os.Stderr, "only single grid puzzles can be rated\n")
+		os.Exit(2)
+	}
+	rating, err := p.Rate()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to rate puzzle: %s\n", err)
+		os.Exit(4)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Difficulty: %s\n", rating.Difficulty)
+	_, _ = fmt.Fprintf(os.Stdout, "Score: %.1f (%s)\n", rating.Score, rating.Hardest)
+	_, _ = fmt.Fprintf(os.Stdout, "Steps: %d\n", rating.Steps)
+	for technique := sudoku.HiddenSingle; technique <= sudoku.Guess; technique++ {
+		if count := rating.Techniques[technique]; count > 0 {
+			_, _ = fmt.Fprintf(os.Stdout, "  %s: %d\n", technique, count)
+		}
+	}
+}
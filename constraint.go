@@ -0,0 +1,207 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidConstraint is returned when a constraint given to a puzzle refers to cells outside of the puzzle.
+var ErrInvalidConstraint = errors.New("invalid constraint")
+
+// Values is a read-only view of the values in a puzzle, used by constraints to check candidate values.
+type Values interface {
+	// Size returns the puzzle size, which is also the largest value a cell can hold.
+	Size() int
+	// Value returns the value of the cell at the given index, or 0 if the cell is empty.
+	Value(index int) int
+}
+
+// Constraint is a rule that restricts the values cells in a puzzle may hold.
+//
+// Every placement made by the solver is checked against the constraints covering the cell,
+// so a constraint must reject a value as soon as placing it would make the constraint impossible to satisfy.
+// In particular, a value that completes the cells of a constraint must be rejected unless the constraint is satisfied.
+type Constraint interface {
+	// Cells returns the indexes of the cells the constraint covers.
+	Cells() []int
+	// Allowed returns true if value may be placed in the cell at index given the other values in the puzzle.
+	// The current value of the cell at index should be ignored.
+	Allowed(values Values, index int, value int) bool
+}
+
+// Region is a constraint requiring every cell it covers to hold a different value.
+// Rows, columns and sections are all regions.
+//
+// Regions are handled directly by the solver rather than through Allowed, which makes them much cheaper
+// than other constraints. A region that covers puzzle size cells must contain every value exactly once.
+type Region struct {
+	// Kind describes the region, such as row, column or section. It is used in error messages.
+	Kind    string
+	Indexes []int
+}
+
+// Cells returns the indexes of the cells in the region.
+func (r *Region) Cells() []int {
+	return r.Indexes
+}
+
+// Allowed returns true if no other cell in the region holds the given value.
+func (r *Region) Allowed(values Values, index int, value int) bool {
+	for _, i := range r.Indexes {
+		if i != index && values.Value(i) == value {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a description of the region.
+func (r *Region) String() string {
+	return fmt.Sprintf("%s %v", r.Kind, r.Indexes)
+}
+
+// validate returns an error if the region covers a cell more than once,
+// or covers more cells than there are values in a puzzle of the given size.
+func (r *Region) validate(puzzleSize int) error {
+	if len(r.Indexes) > puzzleSize {
+		return fmt.Errorf("%w: %v covers %d cells but can only hold %d different values",
+			ErrInvalidConstraint, r, len(r.Indexes), puzzleSize)
+	}
+	seen := make(map[int]bool, len(r.Indexes))
+	for _, index := range r.Indexes {
+		if seen[index] {
+			return fmt.Errorf("%w: %v contains cell %d more than once", ErrInvalidConstraint, r, index)
+		}
+		seen[index] = true
+	}
+	return nil
+}
+
+// Rows returns a region for each row of a puzzle of the given size.
+func Rows(puzzleSize int) []*Region {
+	rows := make([]*Region, puzzleSize)
+	for i := range rows {
+		rows[i] = &Region{Kind: "row", Indexes: make([]int, 0, puzzleSize)}
+	}
+	for index := 0; index < puzzleSize*puzzleSize; index++ {
+		row := rows[getRowFromIndex(index, puzzleSize)]
+		row.Indexes = append(row.Indexes, index)
+	}
+	return rows
+}
+
+// Columns returns a region for each column of a puzzle of the given size.
+func Columns(puzzleSize int) []*Region {
+	columns := make([]*Region, puzzleSize)
+	for i := range columns {
+		columns[i] = &Region{Kind: "column", Indexes: make([]int, 0, puzzleSize)}
+	}
+	for index := 0; index < puzzleSize*puzzleSize; index++ {
+		column := columns[getColumnFromIndex(index, puzzleSize)]
+		column.Indexes = append(column.Indexes, index)
+	}
+	return columns
+}
+
+// Sections returns a region for each section of a puzzle of the given size,
+// where each section is sectionWidth cells wide and sectionHeight cells tall.
+func Sections(puzzleSize int, sectionWidth int, sectionHeight int) []*Region {
+	sections := make([]*Region, puzzleSize)
+	for i := range sections {
+		sections[i] = &Region{Kind: "section", Indexes: make([]int, 0, puzzleSize)}
+	}
+	for index := 0; index < puzzleSize*puzzleSize; index++ {
+		section := sections[getRectangularSectionFromIndex(index, puzzleSize, sectionWidth, sectionHeight)]
+		section.Indexes = append(section.Indexes, index)
+	}
+	return sections
+}
+
+// Diagonals returns a region for each of the two main diagonals of a puzzle of the given size,
+// as used by Sudoku-X puzzles.
+func Diagonals(puzzleSize int) []*Region {
+	leading := &Region{Kind: "diagonal", Indexes: make([]int, puzzleSize)}
+	trailing := &Region{Kind: "diagonal", Indexes: make([]int, puzzleSize)}
+	for i := 0; i < puzzleSize; i++ {
+		leading.Indexes[i] = i*puzzleSize + i
+		trailing.Indexes[i] = i*puzzleSize + puzzleSize - 1 - i
+	}
+	return []*Region{leading, trailing}
+}
+
+// Windows returns the extra regions of a Hyper or Windoku puzzle of the given size, where each region
+// is the size of a section. Windows are placed one cell in from the top left of the puzzle,
+// with one cell between each window, so a 9x9 puzzle has four 3x3 windows.
+func Windows(puzzleSize int, sectionWidth int, sectionHeight int) []*Region {
+	var windows []*Region
+	for top := 1; top+sectionHeight <= puzzleSize; top += sectionHeight + 1 {
+		for left := 1; left+sectionWidth <= puzzleSize; left += sectionWidth + 1 {
+			window := &Region{Kind: "window", Indexes: make([]int, 0, puzzleSize)}
+			for row := top; row < top+sectionHeight; row++ {
+				for column := left; column < left+sectionWidth; column++ {
+					window.Indexes = append(window.Indexes, row*puzzleSize+column)
+				}
+			}
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// KnightMoves returns a region for every pair of cells a chess knight's move apart in a puzzle of the given size,
+// as used by anti-knight puzzles where those cells cannot hold the same value.
+func KnightMoves(puzzleSize int) []*Region {
+	return moves(puzzleSize, "anti-knight", [][2]int{{1, -2}, {1, 2}, {2, -1}, {2, 1}})
+}
+
+// KingMoves returns a region for every pair of diagonally adjacent cells in a puzzle of the given size,
+// as used by anti-king puzzles where cells a chess king's move apart cannot hold the same value.
+// Cells that are horizontally or vertically adjacent are already in the same row or column, so they are not included.
+func KingMoves(puzzleSize int) []*Region {
+	return moves(puzzleSize, "anti-king", [][2]int{{1, -1}, {1, 1}})
+}
+
+// moves returns a region of the given kind for every pair of cells in a puzzle of the given size that are
+// separated by one of the given row and column offsets.
+// Offsets only move down the puzzle so each pair is returned once.
+func moves(puzzleSize int, kind string, offsets [][2]int) []*Region {
+	var regions []*Region
+	for index := 0; index < puzzleSize*puzzleSize; index++ {
+		row, column := getRowFromIndex(index, puzzleSize), getColumnFromIndex(index, puzzleSize)
+		for _, offset := range offsets {
+			r, c := row+offset[0], column+offset[1]
+			if r < 0 || r >= puzzleSize || c < 0 || c >= puzzleSize {
+				continue
+			}
+			regions = append(regions, &Region{Kind: kind, Indexes: []int{index, r*puzzleSize + c}})
+		}
+	}
+	return regions
+}
+
+// validator is implemented by constraints that can check they are able to be satisfied
+// before the puzzle is solved.
+type validator interface {
+	validate(puzzleSize int) error
+}
+
+// validateConstraints returns an error wrapping ErrInvalidConstraint if any constraint covers a cell
+// that is not in a puzzle of the given size, or can never be satisfied.
+func validateConstraints(constraints []Constraint, puzzleSize int, cellCount int) error {
+	for _, c := range constraints {
+		if c == nil {
+			return fmt.Errorf("%w: constraint is nil", ErrInvalidConstraint)
+		}
+		for _, index := range c.Cells() {
+			if index < 0 || index >= cellCount {
+				return fmt.Errorf("%w: %v covers cell %d which is outside of the puzzle", ErrInvalidConstraint, c, index)
+			}
+		}
+		if v, ok := c.(validator); ok {
+			if err := v.validate(puzzleSize); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
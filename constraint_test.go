@@ -0,0 +1,720 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lessThan is a constraint requiring the value of cell a to be less than the value of cell b.
+type lessThan struct {
+	a int
+	b int
+}
+
+func (c lessThan) Cells() []int {
+	return []int{c.a, c.b}
+}
+
+func (c lessThan) Allowed(values Values, index int, value int) bool {
+	switch index {
+	case c.a:
+		other := values.Value(c.b)
+		return other == 0 || value < other
+	case c.b:
+		other := values.Value(c.a)
+		return other == 0 || other < value
+	}
+	return true
+}
+
+func TestPuzzle_Constraints_Solve(t *testing.T) {
+	corners := &Region{Kind: "corners", Indexes: []int{0, 3, 12, 15}}
+	tests := []struct {
+		Name        string
+		Constraints []Constraint
+		Exp         int
+	}{
+		{Name: "None", Exp: 288},
+		{Name: "Region", Constraints: []Constraint{corners}, Exp: 168},
+		{Name: "Custom", Constraints: []Constraint{lessThan{a: 0, b: 1}}, Exp: 144},
+		{Name: "RegionAndCustom", Constraints: []Constraint{corners, lessThan{a: 0, b: 1}}, Exp: 84},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			t.Run(fmt.Sprintf("%s/%s", tc.Name, config.Name), func(t *testing.T) {
+				in := make([]int, 16)
+				p, err := NewPuzzle(in, append(config.Options, WithConstraints(tc.Constraints...))...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				count := 0
+				it := p.Solutions()
+				for it.Next() {
+					got := it.Solution()
+					checkSolution(t, in, got)
+					for _, c := range tc.Constraints {
+						for _, index := range c.Cells() {
+							if !c.Allowed(valueSlice(got), index, got[index]) {
+								t.Errorf("solution %v breaks constraint %v at cell %d", got, c, index)
+							}
+						}
+					}
+					count++
+				}
+				if err := it.Err(); err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				if tc.Exp != count {
+					t.Errorf("expected %d solutions, got %d", tc.Exp, count)
+				}
+			})
+		}
+	}
+}
+
+// valueSlice implements Values for a slice of values.
+type valueSlice []int
+
+func (v valueSlice) Size() int {
+	size, _ := CalculatePuzzleSize(v)
+	return size
+}
+
+func (v valueSlice) Value(index int) int {
+	return v[index]
+}
+
+func TestPuzzle_Constraints(t *testing.T) {
+	extra := lessThan{a: 0, b: 1}
+	p, err := NewPuzzle(make([]int, 16), WithConstraints(extra))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	got := p.Constraints()
+	if exp := 4*3 + 1; len(got) != exp {
+		t.Errorf("expected %d constraints, got %d", exp, len(got))
+		return
+	}
+	if exp := (&Region{Kind: "row", Indexes: []int{0, 1, 2, 3}}); !reflect.DeepEqual(exp, got[0]) {
+		t.Errorf("expected first constraint to be %v, got %v", exp, got[0])
+	}
+	if exp := (&Region{Kind: "section", Indexes: []int{10, 11, 14, 15}}); !reflect.DeepEqual(exp, got[11]) {
+		t.Errorf("expected last section to be %v, got %v", exp, got[11])
+	}
+	if !reflect.DeepEqual(extra, got[12]) {
+		t.Errorf("expected last constraint to be %v, got %v", extra, got[12])
+	}
+}
+
+func TestNewPuzzle_ConstraintValidation(t *testing.T) {
+	t.Run("RegionConflict", func(t *testing.T) {
+		_, err := NewPuzzle([]int{
+			1, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 1,
+		}, WithConstraints(&Region{Kind: "diagonal", Indexes: []int{0, 5, 10, 15}}))
+		var got *ConflictError
+		if !errors.As(err, &got) {
+			t.Errorf("expected *ConflictError, got %v", err)
+			return
+		}
+		if got.Unit != "diagonal" || got.Cell.Index != 15 || got.Other.Index != 0 {
+			t.Errorf("unexpected conflict: %+v", got)
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		c := lessThan{a: 0, b: 1}
+		_, err := NewPuzzle([]int{
+			3, 2, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+		}, WithConstraints(c))
+		var got *ConstraintError
+		if !errors.As(err, &got) {
+			t.Errorf("expected *ConstraintError, got %v", err)
+			return
+		}
+		exp := &ConstraintError{
+			CellPosition: CellPosition{Index: 0, Row: 0, Column: 0, Section: 0},
+			Value:        3,
+			Constraint:   c,
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("expected %+v, got %+v", exp, got)
+		}
+	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		_, err := NewPuzzle(make([]int, 16), WithConstraints(lessThan{a: 0, b: 16}))
+		if !errors.Is(err, ErrInvalidConstraint) {
+			t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+		}
+	})
+}
+
+func ExampleWithConstraints() {
+	// the four corners of the puzzle must also hold different values.
+	corners := &Region{Kind: "corners", Indexes: []int{0, 3, 12, 15}}
+	p, _ := NewPuzzle(make([]int, 16), WithConstraints(corners))
+	count, _ := p.CountSolutions(0)
+	fmt.Println(count)
+
+	// Output:
+	// 168
+}
+
+func TestDiagonals(t *testing.T) {
+	exp := []*Region{
+		{Kind: "diagonal", Indexes: []int{0, 5, 10, 15}},
+		{Kind: "diagonal", Indexes: []int{3, 6, 9, 12}},
+	}
+	if got := Diagonals(4); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+// checkDiagonals checks that both main diagonals of the given solution contain every value once.
+func checkDiagonals(t *testing.T, got []int) {
+	t.Helper()
+	puzzleSize, _ := CalculatePuzzleSize(got)
+	for _, r := range Diagonals(puzzleSize) {
+		seen := map[int]bool{}
+		for _, index := range r.Indexes {
+			if seen[got[index]] {
+				t.Errorf("value %d repeated on diagonal %v", got[index], r.Indexes)
+			}
+			seen[got[index]] = true
+		}
+	}
+}
+
+func TestPuzzle_Solve_Diagonals(t *testing.T) {
+	in := []int{
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 5, 0, 3, 7, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 2, 3, 0,
+		0, 0, 0, 6, 0, 0, 0, 1, 0,
+		2, 0, 0, 0, 0, 0, 4, 9, 0,
+		0, 0, 0, 7, 0, 0, 0, 0, 2,
+		0, 1, 0, 0, 0, 8, 7, 0, 5,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 9, 0, 0, 0, 0, 1, 0, 0,
+	}
+	exp := []int{
+		9, 3, 6, 8, 4, 2, 5, 7, 1,
+		4, 2, 5, 1, 3, 7, 9, 6, 8,
+		7, 8, 1, 9, 6, 5, 2, 3, 4,
+		3, 5, 4, 6, 2, 9, 8, 1, 7,
+		2, 7, 8, 3, 5, 1, 4, 9, 6,
+		1, 6, 9, 7, 8, 4, 3, 5, 2,
+		6, 1, 3, 4, 9, 8, 7, 2, 5,
+		5, 4, 7, 2, 1, 3, 6, 8, 9,
+		8, 9, 2, 5, 7, 6, 1, 4, 3,
+	}
+
+	for _, config := range solveConfigs {
+		if config.Name == "linear/propagation=false" {
+			// brute force in index order takes several seconds on this puzzle.
+			continue
+		}
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(in, append(config.Options, WithDiagonals())...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+			rate, err := p.CompletionRate()
+			if err != nil {
+				t.Errorf("could not get completion rate: %s", err)
+				return
+			}
+			if !rate.Completed || rate.Failed {
+				t.Errorf("expected puzzle to be completed, got %+v", rate)
+			}
+		})
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		p, err := NewPuzzle(in, WithDiagonals())
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, err := p.HasUniqueSolution(); !unique {
+			t.Errorf("expected puzzle to be unique, got %v", err)
+		}
+	})
+
+	t.Run("Classic", func(t *testing.T) {
+		// without the diagonals the puzzle has more than one solution.
+		p, err := NewPuzzle(in)
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, _ := p.HasUniqueSolution(); unique {
+			t.Errorf("expected puzzle not to be unique without diagonals")
+		}
+	})
+}
+
+func TestPuzzle_Solve_Diagonals_Sizes(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Size    int
+		Options []Option
+	}{
+		{Name: "4x4", Size: 4},
+		{Name: "6x6", Size: 6, Options: []Option{WithSectionSize(3, 2)}},
+		{Name: "9x9", Size: 9},
+		{Name: "16x16", Size: 16},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			if tc.Size > 9 && strings.HasPrefix(config.Name, "linear") {
+				// filling cells in order gets stuck for a very long time once the diagonals conflict.
+				continue
+			}
+			t.Run(fmt.Sprintf("%s/%s", tc.Name, config.Name), func(t *testing.T) {
+				in := make([]int, tc.Size*tc.Size)
+				opts := append(append(tc.Options, config.Options...), WithDiagonals())
+				p, err := NewPuzzle(in, opts...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				if err := p.Solve(); err != nil {
+					t.Errorf("could not solve puzzle: %s", err)
+					return
+				}
+				got, err := p.Result()
+				if err != nil {
+					t.Errorf("could not get result: %s", err)
+					return
+				}
+				checkDiagonals(t, got)
+			})
+		}
+	}
+}
+
+func TestPuzzle_CountSolutions_Diagonals(t *testing.T) {
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(make([]int, 16), append(config.Options, WithDiagonals())...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			got, err := p.CountSolutions(0)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if exp := 48; exp != got {
+				t.Errorf("expected %d, got %d", exp, got)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_Diagonals_Conflict(t *testing.T) {
+	_, err := NewPuzzle([]int{
+		0, 0, 0, 2,
+		0, 0, 0, 0,
+		0, 2, 0, 0,
+		0, 0, 0, 0,
+	}, WithDiagonals())
+	var got *ConflictError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConflictError, got %v", err)
+		return
+	}
+	if got.Unit != "diagonal" || got.Cell.Index != 9 || got.Other.Index != 3 {
+		t.Errorf("unexpected conflict: %+v", got)
+	}
+}
+
+func TestWindows(t *testing.T) {
+	tests := []struct {
+		Name          string
+		PuzzleSize    int
+		SectionWidth  int
+		SectionHeight int
+		Exp           []*Region
+	}{
+		{
+			Name:       "4x4",
+			PuzzleSize: 4, SectionWidth: 2, SectionHeight: 2,
+			Exp: []*Region{
+				{Kind: "window", Indexes: []int{5, 6, 9, 10}},
+			},
+		},
+		{
+			Name:       "9x9",
+			PuzzleSize: 9, SectionWidth: 3, SectionHeight: 3,
+			Exp: []*Region{
+				{Kind: "window", Indexes: []int{10, 11, 12, 19, 20, 21, 28, 29, 30}},
+				{Kind: "window", Indexes: []int{14, 15, 16, 23, 24, 25, 32, 33, 34}},
+				{Kind: "window", Indexes: []int{46, 47, 48, 55, 56, 57, 64, 65, 66}},
+				{Kind: "window", Indexes: []int{50, 51, 52, 59, 60, 61, 68, 69, 70}},
+			},
+		},
+		{
+			Name:       "6x6",
+			PuzzleSize: 6, SectionWidth: 3, SectionHeight: 2,
+			Exp: []*Region{
+				{Kind: "window", Indexes: []int{7, 8, 9, 13, 14, 15}},
+				{Kind: "window", Indexes: []int{25, 26, 27, 31, 32, 33}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := Windows(tc.PuzzleSize, tc.SectionWidth, tc.SectionHeight)
+			if !reflect.DeepEqual(tc.Exp, got) {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Solve_Windows(t *testing.T) {
+	in := []int{
+		0, 1, 0, 0, 0, 0, 0, 0, 5,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		6, 0, 0, 4, 0, 0, 0, 7, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 7,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 9, 0, 8, 0, 0, 3, 4,
+		1, 5, 0, 0, 0, 0, 0, 0, 6,
+		0, 0, 0, 0, 0, 2, 0, 0, 0,
+		0, 0, 3, 0, 0, 0, 0, 0, 0,
+	}
+	exp := []int{
+		9, 1, 4, 7, 6, 8, 3, 2, 5,
+		5, 2, 7, 9, 1, 3, 4, 6, 8,
+		6, 3, 8, 4, 2, 5, 1, 7, 9,
+		4, 6, 1, 5, 3, 9, 2, 8, 7,
+		3, 8, 5, 2, 7, 4, 6, 9, 1,
+		2, 7, 9, 1, 8, 6, 5, 3, 4,
+		1, 5, 2, 3, 9, 7, 8, 4, 6,
+		7, 4, 6, 8, 5, 2, 9, 1, 3,
+		8, 9, 3, 6, 4, 1, 7, 5, 2,
+	}
+
+	for _, config := range solveConfigs {
+		if config.Name == "linear/propagation=false" {
+			// brute force in index order takes several seconds on this puzzle.
+			continue
+		}
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(in, append(config.Options, WithWindows())...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+		})
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		p, err := NewPuzzle(in, WithWindows())
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, err := p.HasUniqueSolution(); !unique {
+			t.Errorf("expected puzzle to be unique, got %v", err)
+		}
+	})
+
+	t.Run("Classic", func(t *testing.T) {
+		// without the windows the puzzle has more than one solution.
+		p, err := NewPuzzle(in)
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, _ := p.HasUniqueSolution(); unique {
+			t.Errorf("expected puzzle not to be unique without windows")
+		}
+	})
+}
+
+func TestNewPuzzle_Windows_Conflict(t *testing.T) {
+	_, err := NewPuzzle([]int{
+		0, 0, 0, 0,
+		0, 3, 0, 0,
+		0, 0, 3, 0,
+		0, 0, 0, 0,
+	}, WithWindows())
+	var got *ConflictError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConflictError, got %v", err)
+		return
+	}
+	if got.Unit != "window" || got.Cell.Index != 10 || got.Other.Index != 5 {
+		t.Errorf("unexpected conflict: %+v", got)
+	}
+}
+
+func TestWithRegions(t *testing.T) {
+	t.Run("Solve", func(t *testing.T) {
+		// the corners of the puzzle must hold different values.
+		corners := &Region{Kind: "corners", Indexes: []int{0, 3, 12, 15}}
+		p, err := NewPuzzle([]int{
+			1, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+		}, WithRegions(corners))
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		for it := p.Solutions(); it.Next(); {
+			got := it.Solution()
+			checkSolution(t, make([]int, 16), got)
+			if !corners.Allowed(valueSlice(got), 0, got[0]) || !corners.Allowed(valueSlice(got), 3, got[3]) ||
+				!corners.Allowed(valueSlice(got), 12, got[12]) {
+				t.Errorf("expected corners to hold different values, got %v", got)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tests := []struct {
+			Name   string
+			Region *Region
+		}{
+			{Name: "TooManyCells", Region: &Region{Kind: "extra", Indexes: []int{0, 1, 2, 3, 4}}},
+			{Name: "RepeatedCell", Region: &Region{Kind: "extra", Indexes: []int{0, 1, 1}}},
+		}
+		for _, tc := range tests {
+			t.Run(tc.Name, func(t *testing.T) {
+				_, err := NewPuzzle(make([]int, 16), WithRegions(tc.Region))
+				if !errors.Is(err, ErrInvalidConstraint) {
+					t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+				}
+			})
+		}
+	})
+}
+
+func TestKnightMoves(t *testing.T) {
+	exp := []*Region{
+		{Kind: "anti-knight", Indexes: []int{0, 5}},
+		{Kind: "anti-knight", Indexes: []int{0, 7}},
+		{Kind: "anti-knight", Indexes: []int{1, 6}},
+		{Kind: "anti-knight", Indexes: []int{1, 8}},
+		{Kind: "anti-knight", Indexes: []int{2, 3}},
+		{Kind: "anti-knight", Indexes: []int{2, 7}},
+		{Kind: "anti-knight", Indexes: []int{3, 8}},
+		{Kind: "anti-knight", Indexes: []int{5, 6}},
+	}
+	if got := KnightMoves(3); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestKingMoves(t *testing.T) {
+	exp := []*Region{
+		{Kind: "anti-king", Indexes: []int{0, 4}},
+		{Kind: "anti-king", Indexes: []int{1, 3}},
+		{Kind: "anti-king", Indexes: []int{1, 5}},
+		{Kind: "anti-king", Indexes: []int{2, 4}},
+		{Kind: "anti-king", Indexes: []int{3, 7}},
+		{Kind: "anti-king", Indexes: []int{4, 6}},
+		{Kind: "anti-king", Indexes: []int{4, 8}},
+		{Kind: "anti-king", Indexes: []int{5, 7}},
+	}
+	if got := KingMoves(3); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+// checkMoves checks that no two cells of the given solution a move apart hold the same value.
+func checkMoves(t *testing.T, moves []*Region, got []int) {
+	t.Helper()
+	for _, r := range moves {
+		if got[r.Indexes[0]] == got[r.Indexes[1]] {
+			t.Errorf("value %d repeated in %v", got[r.Indexes[0]], r)
+		}
+	}
+}
+
+func TestPuzzle_Solve_AntiChess(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Option Option
+		Moves  []*Region
+		In     []int
+		Exp    []int
+	}{
+		{
+			Name:   "AntiKnight",
+			Option: WithAntiKnight(),
+			Moves:  KnightMoves(9),
+			In: []int{
+				0, 0, 0, 0, 0, 0, 3, 0, 0,
+				0, 0, 0, 0, 4, 0, 0, 0, 0,
+				0, 0, 0, 9, 1, 5, 0, 0, 0,
+				0, 0, 0, 2, 0, 0, 0, 0, 8,
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 1, 9, 0, 0, 6, 0,
+				0, 0, 0, 6, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 2, 0, 0, 0, 8, 0, 0,
+			},
+			Exp: []int{
+				1, 4, 5, 7, 6, 2, 3, 8, 9,
+				7, 6, 9, 8, 4, 3, 2, 1, 5,
+				2, 3, 8, 9, 1, 5, 6, 4, 7,
+				9, 1, 3, 2, 5, 6, 4, 7, 8,
+				6, 5, 7, 3, 8, 4, 1, 9, 2,
+				8, 2, 4, 1, 9, 7, 5, 6, 3,
+				3, 8, 1, 6, 2, 9, 7, 5, 4,
+				4, 7, 6, 5, 3, 8, 9, 2, 1,
+				5, 9, 2, 4, 7, 1, 8, 3, 6,
+			},
+		},
+		{
+			Name:   "AntiKing",
+			Option: WithAntiKing(),
+			Moves:  KingMoves(9),
+			In: []int{
+				3, 0, 0, 0, 8, 0, 0, 0, 9,
+				0, 5, 0, 0, 0, 0, 0, 6, 1,
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 7, 0, 5, 0, 6,
+				4, 0, 5, 1, 0, 0, 0, 0, 0,
+				0, 7, 6, 0, 9, 0, 0, 0, 0,
+				0, 2, 0, 0, 1, 0, 0, 0, 0,
+				0, 6, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 3, 1, 0, 0,
+			},
+			Exp: []int{
+				3, 4, 7, 6, 8, 1, 2, 5, 9,
+				9, 5, 8, 4, 2, 7, 3, 6, 1,
+				6, 1, 2, 3, 5, 9, 8, 4, 7,
+				2, 3, 9, 8, 7, 4, 5, 1, 6,
+				4, 8, 5, 1, 3, 6, 9, 7, 2,
+				1, 7, 6, 2, 9, 5, 4, 8, 3,
+				5, 2, 3, 7, 1, 8, 6, 9, 4,
+				8, 6, 1, 9, 4, 2, 7, 3, 5,
+				7, 9, 4, 5, 6, 3, 1, 2, 8,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			t.Run(tc.Name+"/"+config.Name, func(t *testing.T) {
+				p, err := NewPuzzle(tc.In, append(config.Options, tc.Option)...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				if err := p.Solve(); err != nil {
+					t.Errorf("could not solve puzzle: %s", err)
+					return
+				}
+				got, err := p.Result()
+				if err != nil {
+					t.Errorf("could not get result: %s", err)
+					return
+				}
+				if !reflect.DeepEqual(tc.Exp, got) {
+					t.Errorf("expected %v, got %v", tc.Exp, got)
+				}
+				checkMoves(t, tc.Moves, got)
+			})
+		}
+
+		t.Run(tc.Name+"/Unique", func(t *testing.T) {
+			p, err := NewPuzzle(tc.In, tc.Option)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if unique, err := p.HasUniqueSolution(); !unique {
+				t.Errorf("expected puzzle to be unique, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Solve_AntiKnightAndAntiKing(t *testing.T) {
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(make([]int, 81), append(config.Options, WithAntiKnight(), WithAntiKing())...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			checkSolution(t, make([]int, 81), got)
+			checkMoves(t, KnightMoves(9), got)
+			checkMoves(t, KingMoves(9), got)
+		})
+	}
+}
+
+func TestNewPuzzle_AntiKnight_Conflict(t *testing.T) {
+	in := make([]int, 81)
+	in[2] = 5
+	in[13] = 5
+	_, err := NewPuzzle(in, WithAntiKnight())
+	var got *ConflictError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConflictError, got %v", err)
+		return
+	}
+	if got.Unit != "anti-knight" || got.Cell.Index != 13 || got.Other.Index != 2 {
+		t.Errorf("unexpected conflict: %+v", got)
+	}
+}
@@ -0,0 +1,336 @@
+package sudoku
+
+import (
+	"context"
+)
+
+// dancingLinks is an exact cover solver using Knuth's Algorithm X with dancing links.
+//
+// Each column of the matrix is a requirement of the puzzle, such as an empty cell needing a value
+// or a row needing a value of 5. Each row of the matrix is a possible move and covers every requirement
+// that placing the move would satisfy.
+//
+// The links are stored as indexes into slices rather than pointers.
+// Node 0 is the root, followed by the column headers and then the nodes of each row.
+type dancingLinks struct {
+	left   []int
+	right  []int
+	up     []int
+	down   []int
+	column []int
+	// size holds the number of rows remaining in each column.
+	size []int
+	// moves holds the move represented by each node.
+	moves []move
+	// frames holds the column and row chosen at each level of the search.
+	frames []dancingLinksFrame
+
+	// accept is called before a move is selected, and the move is skipped if it returns false.
+	// It may be nil if every move is acceptable.
+	accept func(m move) bool
+	// onSelect and onUnselect are called as moves are made and undone.
+	// forced is true if the move was the only option left for a requirement.
+	onSelect   func(m move, forced bool)
+	onUnselect func(m move)
+}
+
+// dancingLinksFrame is a single level of the dancing links search.
+type dancingLinksFrame struct {
+	column int
+	row    int
+}
+
+// newDancingLinks returns a dancing links matrix for the empty cells in the given grid.
+// Constraints other than regions cannot be expressed as an exact cover, so moves that break them
+// are rejected using accept as the search reaches them.
+func newDancingLinks(g *grid) *dancingLinks {
+	d := &dancingLinks{
+		left:   []int{0},
+		right:  []int{0},
+		up:     []int{0},
+		down:   []int{0},
+		column: []int{0},
+		size:   []int{0},
+		moves:  []move{{}},
+	}
+
+	// every empty cell requires a value.
+	cellColumns := make([]int, len(g.cells))
+	for _, c := range g.cells {
+		if c.value == 0 {
+			cellColumns[c.index] = d.addColumn()
+		}
+	}
+
+	// every region requires each of its missing values.
+	// regions that don't cover every value can hold each value at most once, so they use secondary columns.
+	regionColumns := make([][]int, len(g.regions))
+	for i, r := range g.regions {
+		regionColumns[i] = make([]int, g.puzzleSize)
+		for value := 1; value <= g.puzzleSize; value++ {
+			switch {
+			case g.regionValues[i].has(value):
+			case len(r.Indexes) == g.puzzleSize:
+				regionColumns[i][value-1] = d.addColumn()
+			default:
+				regionColumns[i][value-1] = d.addSecondaryColumn()
+			}
+		}
+	}
+
+	// every candidate of every empty cell is a possible move.
+	columns := make([]int, 0, 4)
+	for _, c := range g.cells {
+		if c.value != 0 {
+			continue
+		}
+		candidates := g.candidates(c)
+		for value := 1; value <= g.puzzleSize; value++ {
+			if !candidates.has(value) {
+				continue
+			}
+			columns = append(columns[:0], cellColumns[c.index])
+			for _, r := range g.cellRegions[c.index] {
+				columns = append(columns, regionColumns[r][value-1])
+			}
+			d.addRow(columns, move{index: c.index, value: value})
+		}
+	}
+
+	if len(g.constraints) > 0 {
+		d.accept = func(m move) bool {
+			return g.allowed(g.cells[m.index], m.value)
+		}
+	}
+
+	return d
+}
+
+// addNode adds a new unlinked node and returns its index.
+func (d *dancingLinks) addNode(column int, m move) int {
+	n := len(d.left)
+	d.left = append(d.left, n)
+	d.right = append(d.right, n)
+	d.up = append(d.up, n)
+	d.down = append(d.down, n)
+	d.column = append(d.column, column)
+	d.size = append(d.size, 0)
+	d.moves = append(d.moves, m)
+	return n
+}
+
+// addColumn adds a new column header to the end of the header list and returns its index.
+func (d *dancingLinks) addColumn() int {
+	n := d.addNode(0, move{})
+	d.column[n] = n
+	d.left[n] = d.left[0]
+	d.right[n] = 0
+	d.right[d.left[0]] = n
+	d.left[0] = n
+	return n
+}
+
+// addSecondaryColumn adds a new column header that is not part of the header list and returns its index.
+// Secondary columns can be covered at most once but do not have to be covered for a solution to be found.
+func (d *dancingLinks) addSecondaryColumn() int {
+	n := d.addNode(0, move{})
+	d.column[n] = n
+	return n
+}
+
+// addRow adds a row for the given move that covers the given columns.
+func (d *dancingLinks) addRow(columns []int, m move) {
+	first := -1
+	for _, c := range columns {
+		n := d.addNode(c, m)
+
+		// link the node to the bottom of its column.
+		d.up[n] = d.up[c]
+		d.down[n] = c
+		d.down[d.up[c]] = n
+		d.up[c] = n
+		d.size[c]++
+
+		// link the node to the end of the row.
+		if first < 0 {
+			first = n
+			continue
+		}
+		d.left[n] = d.left[first]
+		d.right[n] = first
+		d.right[d.left[first]] = n
+		d.left[first] = n
+	}
+}
+
+// cover removes the given column from the header list, along with every row that covers it.
+func (d *dancingLinks) cover(c int) {
+	d.right[d.left[c]] = d.right[c]
+	d.left[d.right[c]] = d.left[c]
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.column[j]]--
+		}
+	}
+}
+
+// uncover reverses cover.
+func (d *dancingLinks) uncover(c int) {
+	for i := d.up[c]; i != c; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.column[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[c]] = c
+	d.left[d.right[c]] = c
+}
+
+// selectRow covers every other column covered by the given row.
+func (d *dancingLinks) selectRow(r int, forced bool) {
+	for j := d.right[r]; j != r; j = d.right[j] {
+		d.cover(d.column[j])
+	}
+	if d.onSelect != nil {
+		d.onSelect(d.moves[r], forced)
+	}
+}
+
+// unselectRow reverses selectRow.
+func (d *dancingLinks) unselectRow(r int) {
+	if d.onUnselect != nil {
+		d.onUnselect(d.moves[r])
+	}
+	for j := d.left[r]; j != r; j = d.left[j] {
+		d.uncover(d.column[j])
+	}
+}
+
+// nextRow returns the first row after r in column c that is accepted, or c if there are none left.
+func (d *dancingLinks) nextRow(c int, r int) int {
+	for r = d.down[r]; r != c; r = d.down[r] {
+		if d.accept == nil || d.accept(d.moves[r]) {
+			return r
+		}
+	}
+	return c
+}
+
+// chooseColumn returns the column with the fewest rows remaining.
+func (d *dancingLinks) chooseColumn() int {
+	best := d.right[0]
+	for c := d.right[best]; c != 0; c = d.right[c] {
+		if d.size[c] < d.size[best] {
+			best = c
+			if d.size[best] == 0 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// search continues the search until the next solution is found, leaving the moves of the solution selected.
+// If the previous call found a solution, skip must be true so the search backtracks to look for the next one.
+// It returns false once there are no more solutions.
+// backtracked is called every time the search has to undo a move.
+func (d *dancingLinks) search(ctx context.Context, skip bool, backtracked func()) (bool, error) {
+	backtrack := skip
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		if !backtrack {
+			if d.right[0] == 0 {
+				// every requirement has been met.
+				return true, nil
+			}
+			c := d.chooseColumn()
+			r := d.nextRow(c, c)
+			if r == c {
+				// the requirement can no longer be met.
+				backtrack = true
+				continue
+			}
+			// the column size also counts rows that accept would reject, so only the accepted rows are counted.
+			forced := d.nextRow(c, r) == c
+			d.cover(c)
+			d.frames = append(d.frames, dancingLinksFrame{column: c, row: r})
+			d.selectRow(r, forced)
+			continue
+		}
+
+		if len(d.frames) == 0 {
+			return false, nil
+		}
+		f := &d.frames[len(d.frames)-1]
+		d.unselectRow(f.row)
+		if backtracked != nil {
+			backtracked()
+		}
+		f.row = d.nextRow(f.column, f.row)
+		if f.row == f.column {
+			// every row in the column has been tried.
+			d.uncover(f.column)
+			d.frames = d.frames[:len(d.frames)-1]
+			continue
+		}
+		d.selectRow(f.row, false)
+		backtrack = false
+	}
+}
+
+// searchDancingLinks searches for the next solution using Algorithm X.
+// The moves made by the search are kept on the trail so the partial solution can be read while solving.
+// If skip is true the solution in the grid is undone first.
+func (p *Puzzle) searchDancingLinks(ctx context.Context, skip bool) (bool, error) {
+	if p.dlx == nil {
+		p.gridMu.Lock()
+		p.dlx = newDancingLinks(p.grid)
+		p.gridMu.Unlock()
+		p.dlx.onSelect = p.selectDancingLinksMove
+		p.dlx.onUnselect = p.unselectDancingLinksMove
+	}
+
+	return p.dlx.search(ctx, skip, func() {
+		p.attemptedIterationsMu.Lock()
+		p.attemptedIterations++
+		p.attemptedIterationsMu.Unlock()
+	})
+}
+
+// selectDancingLinksMove places a move selected by Algorithm X and records it on the trail.
+// Moves that were the only option left for a requirement are counted as deduced.
+func (p *Puzzle) selectDancingLinksMove(m move, forced bool) {
+	p.gridMu.Lock()
+	defer p.gridMu.Unlock()
+	p.grid.place(p.grid.cells[m.index], m.value)
+	p.trail = append(p.trail, move{index: m.index, value: m.value, deduced: forced})
+	if forced {
+		p.deducedCells++
+	} else {
+		p.guessedCells++
+	}
+	p.index = m.index
+	p.minValue = m.value
+}
+
+// unselectDancingLinksMove undoes the most recent move selected by Algorithm X.
+func (p *Puzzle) unselectDancingLinksMove(move) {
+	p.gridMu.Lock()
+	defer p.gridMu.Unlock()
+	last := p.trail[len(p.trail)-1]
+	p.trail = p.trail[:len(p.trail)-1]
+	p.grid.place(p.grid.cells[last.index], 0)
+	if last.deduced {
+		p.deducedCells--
+	} else {
+		p.guessedCells--
+	}
+}
@@ -0,0 +1,126 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Relation is a rule between the values of the two cells of an Edge.
+type Relation int
+
+const (
+	// GreaterThan requires the first cell to hold a larger value than the second.
+	GreaterThan Relation = iota
+	// Consecutive requires the values to differ by exactly one, as shown by a white Kropki dot.
+	Consecutive
+	// Double requires one value to be twice the other, as shown by a black Kropki dot.
+	Double
+	// NotConsecutive requires the values not to differ by one, as used by non-consecutive puzzles.
+	NotConsecutive
+)
+
+// String returns the name of the relation.
+func (r Relation) String() string {
+	switch r {
+	case GreaterThan:
+		return "greater than"
+	case Consecutive:
+		return "consecutive"
+	case Double:
+		return "double"
+	case NotConsecutive:
+		return "not consecutive"
+	default:
+		return "unknown"
+	}
+}
+
+// holds returns true if the relation is satisfied by the values a and b of the first and second cells.
+func (r Relation) holds(a int, b int) bool {
+	switch r {
+	case GreaterThan:
+		return a > b
+	case Consecutive:
+		return a-b == 1 || b-a == 1
+	case Double:
+		return a == 2*b || b == 2*a
+	case NotConsecutive:
+		return a-b != 1 && b-a != 1
+	default:
+		return false
+	}
+}
+
+// Edge is a constraint between the values of two cells, usually drawn on the edge between them,
+// such as a greater-than sign or a Kropki dot.
+//
+// A value is rejected as soon as no value of the other cell could satisfy the relation,
+// so a 1 can never be placed in the first cell of a GreaterThan edge.
+type Edge struct {
+	Relation Relation
+	A        int
+	B        int
+}
+
+// Cells returns the indexes of the two cells.
+func (e *Edge) Cells() []int {
+	return []int{e.A, e.B}
+}
+
+// Allowed returns true if the relation holds between the value and the value of the other cell,
+// or could hold once the other cell is filled if it is empty.
+func (e *Edge) Allowed(values Values, index int, value int) bool {
+	first := index == e.A
+	other := e.B
+	if !first {
+		other = e.A
+	}
+	if v := values.Value(other); v != 0 {
+		return e.holds(first, value, v)
+	}
+	for v := 1; v <= values.Size(); v++ {
+		if e.holds(first, value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// holds returns true if the relation holds between the value and the value of the other cell,
+// where first is true if value is the value of cell A.
+func (e *Edge) holds(first bool, value int, other int) bool {
+	if first {
+		return e.Relation.holds(value, other)
+	}
+	return e.Relation.holds(other, value)
+}
+
+// String returns a description of the edge.
+func (e *Edge) String() string {
+	return fmt.Sprintf("%v edge between %d and %d", e.Relation, e.A, e.B)
+}
+
+// validate returns an error if the edge is between a cell and itself or has an unknown relation.
+func (e *Edge) validate(puzzleSize int) error {
+	if e.A == e.B {
+		return fmt.Errorf("%w: %v is between a cell and itself", ErrInvalidConstraint, e)
+	}
+	if e.Relation < GreaterThan || e.Relation > NotConsecutive {
+		return fmt.Errorf("%w: %v has an unknown relation", ErrInvalidConstraint, e)
+	}
+	return nil
+}
+
+// NonConsecutiveEdges returns a NotConsecutive edge between every pair of horizontally or vertically adjacent cells
+// in a puzzle of the given size, as used by non-consecutive puzzles.
+func NonConsecutiveEdges(puzzleSize int) []*Edge {
+	var edges []*Edge
+	for index := 0; index < puzzleSize*puzzleSize; index++ {
+		if getColumnFromIndex(index, puzzleSize) < puzzleSize-1 {
+			edges = append(edges, &Edge{Relation: NotConsecutive, A: index, B: index + 1})
+		}
+		if getRowFromIndex(index, puzzleSize) < puzzleSize-1 {
+			edges = append(edges, &Edge{Relation: NotConsecutive, A: index, B: index + puzzleSize})
+		}
+	}
+	return edges
+}
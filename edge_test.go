@@ -0,0 +1,275 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// edgeSolution is the solution used to build the edges of the edge puzzles,
+// which is the same as TestPuzzle_Solve_Diagonals.
+var edgeSolution = []int{
+	9, 3, 6, 8, 4, 2, 5, 7, 1,
+	4, 2, 5, 1, 3, 7, 9, 6, 8,
+	7, 8, 1, 9, 6, 5, 2, 3, 4,
+	3, 5, 4, 6, 2, 9, 8, 1, 7,
+	2, 7, 8, 3, 5, 1, 4, 9, 6,
+	1, 6, 9, 7, 8, 4, 3, 5, 2,
+	6, 1, 3, 4, 9, 8, 7, 2, 5,
+	5, 4, 7, 2, 1, 3, 6, 8, 9,
+	8, 9, 2, 5, 7, 6, 1, 4, 3,
+}
+
+// greaterThanEdges returns a greater-than edge between every pair of adjacent cells within the same 3x3 section,
+// pointing from the larger to the smaller value of the solution.
+func greaterThanEdges(solution []int) []*Edge {
+	var edges []*Edge
+	for _, e := range NonConsecutiveEdges(9) {
+		if getSectionFromIndex(e.A, 9, 3) != getSectionFromIndex(e.B, 9, 3) {
+			continue
+		}
+		if solution[e.A] > solution[e.B] {
+			edges = append(edges, &Edge{Relation: GreaterThan, A: e.A, B: e.B})
+		} else {
+			edges = append(edges, &Edge{Relation: GreaterThan, A: e.B, B: e.A})
+		}
+	}
+	return edges
+}
+
+// kropkiEdges returns a white dot between every pair of adjacent cells holding consecutive values in the solution,
+// and a black dot between every other pair where one value is double the other.
+func kropkiEdges(solution []int) []*Edge {
+	var edges []*Edge
+	for _, e := range NonConsecutiveEdges(9) {
+		a, b := solution[e.A], solution[e.B]
+		switch {
+		case Consecutive.holds(a, b):
+			edges = append(edges, &Edge{Relation: Consecutive, A: e.A, B: e.B})
+		case Double.holds(a, b):
+			edges = append(edges, &Edge{Relation: Double, A: e.A, B: e.B})
+		}
+	}
+	return edges
+}
+
+func TestRelation_holds(t *testing.T) {
+	tests := []struct {
+		Relation Relation
+		A        int
+		B        int
+		Exp      bool
+	}{
+		{Relation: GreaterThan, A: 2, B: 1, Exp: true},
+		{Relation: GreaterThan, A: 1, B: 2, Exp: false},
+		{Relation: GreaterThan, A: 2, B: 2, Exp: false},
+		{Relation: Consecutive, A: 4, B: 5, Exp: true},
+		{Relation: Consecutive, A: 5, B: 4, Exp: true},
+		{Relation: Consecutive, A: 4, B: 6, Exp: false},
+		{Relation: Double, A: 3, B: 6, Exp: true},
+		{Relation: Double, A: 8, B: 4, Exp: true},
+		{Relation: Double, A: 3, B: 5, Exp: false},
+		{Relation: NotConsecutive, A: 4, B: 6, Exp: true},
+		{Relation: NotConsecutive, A: 4, B: 4, Exp: true},
+		{Relation: NotConsecutive, A: 5, B: 4, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d %v %d", tc.A, tc.Relation, tc.B), func(t *testing.T) {
+			if got := tc.Relation.holds(tc.A, tc.B); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestEdge_Allowed(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Edge   *Edge
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		{Name: "GreaterThan", Edge: &Edge{Relation: GreaterThan, A: 0, B: 1}, Values: []int{0, 2, 0, 0}, Index: 0, Value: 3, Exp: true},
+		{Name: "NotGreaterThan", Edge: &Edge{Relation: GreaterThan, A: 0, B: 1}, Values: []int{0, 2, 0, 0}, Index: 0, Value: 1, Exp: false},
+		{Name: "LessThan", Edge: &Edge{Relation: GreaterThan, A: 0, B: 1}, Values: []int{4, 0, 0, 0}, Index: 1, Value: 3, Exp: true},
+		// nothing can be greater than the largest value, or less than the smallest.
+		{Name: "NoLargerValue", Edge: &Edge{Relation: GreaterThan, A: 0, B: 1}, Values: make([]int, 4), Index: 1, Value: 4, Exp: false},
+		{Name: "NoSmallerValue", Edge: &Edge{Relation: GreaterThan, A: 0, B: 1}, Values: make([]int, 4), Index: 0, Value: 1, Exp: false},
+		{Name: "Consecutive", Edge: &Edge{Relation: Consecutive, A: 0, B: 1}, Values: []int{0, 2, 0, 0}, Index: 0, Value: 3, Exp: true},
+		{Name: "NotConsecutive", Edge: &Edge{Relation: Consecutive, A: 0, B: 1}, Values: []int{0, 2, 0, 0}, Index: 0, Value: 4, Exp: false},
+		{Name: "Double", Edge: &Edge{Relation: Double, A: 0, B: 1}, Values: []int{0, 2, 0, 0}, Index: 0, Value: 4, Exp: true},
+		// 3 can't be halved and 6 is too large for a 4x4 puzzle.
+		{Name: "NoDouble", Edge: &Edge{Relation: Double, A: 0, B: 1}, Values: make([]int, 4), Index: 0, Value: 3, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := tc.Edge.Allowed(valueSlice(tc.Values), tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestNonConsecutiveEdges(t *testing.T) {
+	exp := []*Edge{
+		{Relation: NotConsecutive, A: 0, B: 1},
+		{Relation: NotConsecutive, A: 0, B: 2},
+		{Relation: NotConsecutive, A: 1, B: 3},
+		{Relation: NotConsecutive, A: 2, B: 3},
+	}
+	if got := NonConsecutiveEdges(2); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestPuzzle_Solve_Edges(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Options []Option
+		In      []int
+		Exp     []int
+	}{
+		{
+			// the givens of TestPuzzle_Solve_Diagonals, which only has a unique solution with the extra edges.
+			Name:    "GreaterThan",
+			Options: []Option{WithEdges(greaterThanEdges(edgeSolution)...)},
+			In: []int{
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 5, 0, 3, 7, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 2, 3, 0,
+				0, 0, 0, 6, 0, 0, 0, 1, 0,
+				2, 0, 0, 0, 0, 0, 4, 9, 0,
+				0, 0, 0, 7, 0, 0, 0, 0, 2,
+				0, 1, 0, 0, 0, 8, 7, 0, 5,
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 9, 0, 0, 0, 0, 1, 0, 0,
+			},
+			Exp: edgeSolution,
+		},
+		{
+			Name:    "Kropki",
+			Options: []Option{WithEdges(kropkiEdges(edgeSolution)...)},
+			In: []int{
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 5, 0, 3, 7, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 2, 3, 0,
+				0, 0, 0, 6, 0, 0, 0, 1, 0,
+				2, 0, 0, 0, 0, 0, 4, 9, 0,
+				0, 0, 0, 7, 0, 0, 0, 0, 2,
+				0, 1, 0, 0, 0, 8, 7, 0, 5,
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 9, 0, 0, 0, 0, 1, 0, 0,
+			},
+			Exp: edgeSolution,
+		},
+		{
+			Name:    "NonConsecutive",
+			Options: []Option{WithNonConsecutive()},
+			In: []int{
+				0, 0, 5, 0, 0, 0, 0, 0, 0,
+				0, 0, 8, 0, 1, 3, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 1, 3, 0,
+				0, 0, 0, 3, 0, 0, 0, 7, 0,
+				5, 7, 9, 0, 0, 0, 8, 1, 0,
+				0, 0, 0, 9, 0, 0, 0, 0, 6,
+				0, 5, 0, 0, 0, 2, 6, 0, 4,
+				0, 0, 0, 0, 0, 5, 0, 0, 7,
+				0, 2, 0, 0, 0, 0, 3, 0, 0,
+			},
+			Exp: []int{
+				1, 3, 5, 2, 7, 9, 4, 6, 8,
+				4, 6, 8, 5, 1, 3, 7, 9, 2,
+				7, 9, 2, 8, 4, 6, 1, 3, 5,
+				2, 4, 6, 3, 8, 1, 5, 7, 9,
+				5, 7, 9, 6, 2, 4, 8, 1, 3,
+				8, 1, 3, 9, 5, 7, 2, 4, 6,
+				3, 5, 1, 7, 9, 2, 6, 8, 4,
+				6, 8, 4, 1, 3, 5, 9, 2, 7,
+				9, 2, 7, 4, 6, 8, 3, 5, 1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			t.Run(tc.Name+"/"+config.Name, func(t *testing.T) {
+				p, err := NewPuzzle(tc.In, append(config.Options, tc.Options...)...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				if err := p.Solve(); err != nil {
+					t.Errorf("could not solve puzzle: %s", err)
+					return
+				}
+				got, err := p.Result()
+				if err != nil {
+					t.Errorf("could not get result: %s", err)
+					return
+				}
+				if !reflect.DeepEqual(tc.Exp, got) {
+					t.Errorf("expected %v, got %v", tc.Exp, got)
+				}
+			})
+		}
+
+		t.Run(tc.Name+"/Unique", func(t *testing.T) {
+			p, err := NewPuzzle(tc.In, append(tc.Options, WithCellOrder(MinimumRemainingValuesCellOrder))...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if unique, err := p.HasUniqueSolution(); !unique {
+				t.Errorf("expected puzzle to be unique, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_Edges_Invalid(t *testing.T) {
+	tests := []struct {
+		Name string
+		Edge *Edge
+	}{
+		{Name: "SameCell", Edge: &Edge{Relation: GreaterThan, A: 3, B: 3}},
+		{Name: "UnknownRelation", Edge: &Edge{Relation: Relation(-1), A: 0, B: 1}},
+		{Name: "OutOfRange", Edge: &Edge{Relation: Consecutive, A: 15, B: 16}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(make([]int, 16), WithEdges(tc.Edge))
+			if !errors.Is(err, ErrInvalidConstraint) {
+				t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_Edges_ConstraintError(t *testing.T) {
+	edge := &Edge{Relation: GreaterThan, A: 0, B: 1}
+	_, err := NewPuzzle([]int{
+		1, 2, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	}, WithEdges(edge))
+	var got *ConstraintError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConstraintError, got %v", err)
+		return
+	}
+	exp := &ConstraintError{
+		CellPosition: CellPosition{Index: 0, Row: 0, Column: 0, Section: 0},
+		Value:        1,
+		Constraint:   edge,
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
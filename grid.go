@@ -0,0 +1,233 @@
+package sudoku
+
+// grid is the mutable state of a puzzle being solved.
+// A single grid is shared by the whole search, with moves being undone using the trail when backtracking.
+type grid struct {
+	puzzleSize int
+	layout     *layout
+	cells      group
+	rows       [][]int
+	columns    [][]int
+	sections   [][]int
+
+	// regions holds the rows, columns and sections followed by any extra regions, and cellRegions
+	// holds the indexes of the regions each cell belongs to.
+	regions     []*Region
+	cellRegions [][]int
+	// regionValues holds the values currently placed in each region.
+	// They are updated as values are placed so candidates never need to be recalculated from the cells.
+	regionValues []bitset
+
+	// constraints holds every constraint that is not a region, and cellConstraints the constraints covering each cell.
+	constraints     []Constraint
+	cellConstraints [][]Constraint
+
+	// allowedValues holds every set of allowed values, and cellValues the values each cell may hold.
+	// cellValues is nil when no cells are restricted.
+	allowedValues []*AllowedValues
+	cellValues    []bitset
+}
+
+// move is a value placed in a cell during the search.
+// Moves are recorded on the trail so that they can be undone.
+type move struct {
+	index int
+	value int
+	// deduced is true if the value was deduced from the grid rather than guessed.
+	deduced bool
+}
+
+// newGrid returns a new grid with the given items and layout.
+func newGrid(items []int, l *layout) *grid {
+	g := &grid{
+		puzzleSize:      l.puzzleSize,
+		layout:          l,
+		cells:           make(group, len(items)),
+		cellRegions:     make([][]int, len(items)),
+		cellConstraints: make([][]Constraint, len(items)),
+	}
+	// most cells belong to a row, column and section, so share a single allocation between them.
+	cellRegions := make([]int, 3*len(items))
+	cells := make([]cell, len(items))
+	for i := range items {
+		cells[i] = cell{
+			fixed: items[i] > 0,
+			index: i,
+		}
+		g.cells[i] = &cells[i]
+		g.cellRegions[i] = cellRegions[3*i : 3*i : 3*i+3]
+	}
+
+	g.rows = g.addRegions(l.rows)
+	g.columns = g.addRegions(l.columns)
+	g.sections = g.addRegions(l.sections)
+	for _, c := range l.constraints {
+		if r, ok := c.(*Region); ok {
+			g.addRegions([]*Region{r})
+			continue
+		}
+		if a, ok := c.(*AllowedValues); ok {
+			g.addAllowedValues(a)
+			continue
+		}
+		g.constraints = append(g.constraints, c)
+		for _, index := range c.Cells() {
+			g.cellConstraints[index] = append(g.cellConstraints[index], c)
+		}
+	}
+
+	g.regionValues = make([]bitset, len(g.regions))
+	for i, item := range items {
+		g.place(g.cells[i], item)
+	}
+	return g
+}
+
+// addRegions adds the given regions to the grid and returns the cell indexes of each one.
+func (g *grid) addRegions(regions []*Region) [][]int {
+	units := make([][]int, len(regions))
+	for i, r := range regions {
+		units[i] = r.Indexes
+		for _, index := range r.Indexes {
+			g.cellRegions[index] = append(g.cellRegions[index], len(g.regions))
+		}
+		g.regions = append(g.regions, r)
+	}
+	return units
+}
+
+// addAllowedValues restricts the cells covered by the given allowed values.
+func (g *grid) addAllowedValues(a *AllowedValues) {
+	if g.cellValues == nil {
+		g.cellValues = make([]bitset, len(g.cells))
+		for i := range g.cellValues {
+			g.cellValues[i] = fullBitset(g.puzzleSize)
+		}
+	}
+	values := a.bitset()
+	for _, index := range a.Indexes {
+		g.cellValues[index] &= values
+	}
+	g.allowedValues = append(g.allowedValues, a)
+}
+
+// Size returns the puzzle size.
+func (g *grid) Size() int {
+	return g.puzzleSize
+}
+
+// Value returns the value of the cell at the given index.
+func (g *grid) Value(index int) int {
+	return g.cells[index].value
+}
+
+// place sets the value of the given cell, keeping the region values up to date.
+func (g *grid) place(c *cell, value int) {
+	for _, r := range g.cellRegions[c.index] {
+		g.regionValues[r] = g.regionValues[r].without(c.value).with(value)
+	}
+	c.value = value
+}
+
+// items returns all of the items in the grid.
+func (g *grid) items() []int {
+	res := make([]int, len(g.cells))
+	for i, c := range g.cells {
+		res[i] = c.value
+	}
+	return res
+}
+
+// nextEmptyCell returns the first cell at or after the given index that has no value,
+// or nil if there are no empty cells left.
+func (g *grid) nextEmptyCell(index int) *cell {
+	for ; index < len(g.cells); index++ {
+		if g.cells[index].value == 0 {
+			return g.cells[index]
+		}
+	}
+	return nil
+}
+
+// mostConstrainedCell returns the empty cell with the fewest candidates, or nil if there are no empty cells left.
+// If multiple cells have the same number of candidates the one with the lowest index is returned.
+func (g *grid) mostConstrainedCell() *cell {
+	var best *cell
+	bestCount := 0
+	for _, c := range g.cells {
+		if c.value != 0 {
+			continue
+		}
+		count := g.candidates(c).count()
+		if best == nil || count < bestCount {
+			best = c
+			bestCount = count
+			// nothing can beat a cell with one or no candidates.
+			if bestCount <= 1 {
+				return best
+			}
+		}
+	}
+	return best
+}
+
+// completionRate returns the number of completed non-fixed cells in the grid.
+func (g *grid) completionRate() *CompletionRate {
+	res := new(CompletionRate)
+	for _, c := range g.cells {
+		res.TotalCells++
+		if c.fixed {
+			res.FixedCells++
+		}
+		if c.value > 0 {
+			res.FilledCells++
+		}
+	}
+	return res
+}
+
+// candidates returns the values that could be placed in the given cell without
+// conflicting with any other cell in its regions or breaking any of its constraints.
+func (g *grid) candidates(cell *cell) bitset {
+	var used bitset
+	for _, r := range g.cellRegions[cell.index] {
+		used |= g.regionValues[r]
+	}
+	// the cells own value shouldn't prevent it from being chosen again.
+	if cell.value > 0 {
+		used = used.without(cell.value)
+	}
+	candidates := fullBitset(g.puzzleSize) &^ used
+	if g.cellValues != nil {
+		candidates &= g.cellValues[cell.index]
+	}
+	if len(g.cellConstraints[cell.index]) == 0 {
+		return candidates
+	}
+	for value := 1; value <= g.puzzleSize; value++ {
+		if candidates.has(value) && !g.allowed(cell, value) {
+			candidates = candidates.without(value)
+		}
+	}
+	return candidates
+}
+
+// allowed returns true if the given value can be placed in the given cell without breaking
+// any of the constraints covering it. Regions are not checked.
+func (g *grid) allowed(cell *cell, value int) bool {
+	for _, c := range g.cellConstraints[cell.index] {
+		if !c.Allowed(g, cell.index, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// findNextValue returns the lowest available value for the given cell that is at least minValue.
+func (g *grid) findNextValue(cell *cell, minValue int) (int, error) {
+	value := g.candidates(cell).from(minValue).lowest()
+	if value == 0 {
+		return 0, ErrNoMoreMoves
+	}
+	return value, nil
+}
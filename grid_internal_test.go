@@ -0,0 +1,107 @@
+package sudoku
+
+import (
+	"testing"
+)
+
+var benchmarkGridItems = []int{
+	6, 0, 0, 0, 0, 0, 1, 5, 0,
+	9, 5, 4, 7, 1, 0, 0, 8, 0,
+	0, 0, 0, 5, 0, 2, 6, 0, 0,
+	8, 0, 0, 0, 9, 4, 0, 0, 6,
+	0, 0, 3, 8, 0, 5, 4, 0, 0,
+	4, 0, 0, 3, 7, 0, 0, 0, 8,
+	0, 0, 6, 9, 0, 3, 0, 0, 0,
+	0, 2, 0, 0, 4, 7, 8, 9, 3,
+	0, 4, 9, 0, 0, 0, 0, 0, 5,
+}
+
+func TestGrid_FindNextValue(t *testing.T) {
+	g := newGrid(benchmarkGridItems, squareLayout(9, Sections(9, 3, 3), nil))
+
+	tests := []struct {
+		Index    int
+		MinValue int
+		Out      int
+		Err      error
+	}{
+		// row 0 has 1, 5, 6. column 1 has 2, 4, 5. section 0 has 4, 5, 6, 9.
+		{Index: 1, MinValue: 1, Out: 3},
+		{Index: 1, MinValue: 4, Out: 7},
+		{Index: 1, MinValue: 8, Out: 8},
+		{Index: 1, MinValue: 9, Err: ErrNoMoreMoves},
+		// row 1 has 1, 4, 5, 7, 8, 9. column 5 has 2, 3, 4, 5, 7. section 1 has 1, 2, 5, 7.
+		{Index: 14, MinValue: 1, Out: 6},
+		{Index: 14, MinValue: 7, Err: ErrNoMoreMoves},
+	}
+
+	for _, tc := range tests {
+		got, err := g.findNextValue(g.cells[tc.Index], tc.MinValue)
+		if err != tc.Err {
+			t.Errorf("index %d min %d: expected error %v, got %v", tc.Index, tc.MinValue, tc.Err, err)
+			continue
+		}
+		if got != tc.Out {
+			t.Errorf("index %d min %d: expected %d, got %d", tc.Index, tc.MinValue, tc.Out, got)
+		}
+	}
+}
+
+func TestGrid_Place(t *testing.T) {
+	g := newGrid(benchmarkGridItems, squareLayout(9, Sections(9, 3, 3), nil))
+	c := g.cells[1]
+
+	// used returns true if the value is used in every region the cell belongs to.
+	used := func(value int) bool {
+		for _, r := range g.cellRegions[c.index] {
+			if !g.regionValues[r].has(value) {
+				return false
+			}
+		}
+		return true
+	}
+	// released returns true if the value is not used in any region the cell belongs to.
+	released := func(value int) bool {
+		for _, r := range g.cellRegions[c.index] {
+			if g.regionValues[r].has(value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if exp, got := 3, len(g.cellRegions[c.index]); exp != got {
+		t.Errorf("expected cell to be in %d regions, got %d", exp, got)
+	}
+
+	g.place(c, 3)
+	if !used(3) {
+		t.Errorf("expected 3 to be used in row, column and section")
+	}
+
+	g.place(c, 7)
+	if !released(3) {
+		t.Errorf("expected 3 to be released from row, column and section")
+	}
+	if !used(7) {
+		t.Errorf("expected 7 to be used in row, column and section")
+	}
+
+	g.place(c, 0)
+	if !released(7) {
+		t.Errorf("expected 7 to be released from row, column and section")
+	}
+}
+
+func BenchmarkGrid_FindNextValue(b *testing.B) {
+	g := newGrid(benchmarkGridItems, squareLayout(9, Sections(9, 3, 3), nil))
+	c := g.cells[1]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.findNextValue(c, 1); err != nil {
+			b.Errorf("unexpected error: %s", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPuzzleSolved is returned when asking for a hint for a puzzle that already has a value in every cell.
+var ErrPuzzleSolved = errors.New("puzzle already solved")
+
+// Hint returns the next step towards solving the puzzle from its givens, see HintWith.
+//
+// A puzzle only holds its givens and the progress of Solve, which would give away values a person solving the puzzle
+// hasn't found yet, so the hint never depends on Solve. Use HintWith to pass in the values entered so far.
+func (p *Puzzle) Hint() (*Step, error) {
+	return p.HintWith(nil)
+}
+
+// HintWith returns the next step towards solving the puzzle from its givens and the values in items,
+// such as those entered by a person solving the puzzle, see CandidatesWith. If items is nil only the givens are used.
+// The hint does not change the puzzle.
+//
+// The step uses the easiest logical technique that makes progress, see Candidates.Next.
+// If only guessing remains the step uses the Guess technique, placing the value from the solution of the puzzle
+// in the empty cell with the fewest candidates. If the puzzle has more than one solution the first one found is used.
+// ErrPuzzleSolved is returned if every cell has a value, and an error wrapping ErrMissingIteration
+// if the values have no solution.
+func (p *Puzzle) HintWith(items []int) (*Step, error) {
+	c, err := p.CandidatesWith(items)
+	if err != nil {
+		return nil, err
+	}
+	if c.Solved() {
+		return nil, ErrPuzzleSolved
+	}
+	s, err := c.Next()
+	if !errors.Is(err, ErrNoLogicalStep) {
+		return s, err
+	}
+	return p.guess(c)
+}
+
+// guess returns a step placing the value from the solution of the given candidates in the empty cell
+// with the fewest candidates.
+func (p *Puzzle) guess(c *Candidates) (*Step, error) {
+	// dancing links copes best with the hard puzzles that need guessing.
+	o := *p.options
+	o.algorithm = DancingLinksAlgorithm
+	solver, err := newPuzzle(c.Items(), p.layout, &o)
+	if err != nil {
+		return nil, err
+	}
+	found, err := solver.search(context.Background(), false)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("could not find a solution: %w", ErrMissingIteration)
+	}
+	index := c.mostConstrainedCell()
+	return &Step{
+		Technique:  Guess,
+		Placements: []Candidate{{Index: index, Value: solver.grid.cells[index].value}},
+	}, nil
+}
@@ -0,0 +1,185 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPuzzle_Hint(t *testing.T) {
+	for _, tc := range techniquePuzzles {
+		t.Run(tc.Technique.String(), func(t *testing.T) {
+			p, err := NewPuzzle(tc.In)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			exp, err := p.Candidates().Next()
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			got, err := p.Hint()
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+			if result, _ := p.Result(); !reflect.DeepEqual(tc.In, result) {
+				t.Errorf("expected the puzzle to be unchanged, got %v", result)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Hint_Guess(t *testing.T) {
+	exp := solve(t, hardPuzzle)
+	p, err := NewPuzzle(hardPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	// enter everything that can be solved logically, leaving values that need guessing.
+	c := p.Candidates()
+	if _, err := c.Solve(); !errors.Is(err, ErrNoLogicalStep) {
+		t.Errorf("expected error %v, got %v", ErrNoLogicalStep, err)
+		return
+	}
+	items := c.Items()
+
+	got, err := p.HintWith(items)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if got.Technique != Guess || len(got.Placements) != 1 || len(got.Eliminations) != 0 {
+		t.Errorf("expected a guess placing a single value, got %v", got)
+		return
+	}
+	placement := got.Placements[0]
+	if placement.Value != exp[placement.Index] {
+		t.Errorf("expected %d in cell %d, got %d", exp[placement.Index], placement.Index, placement.Value)
+	}
+	if c, err = p.CandidatesWith(items); err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	for index := range exp {
+		if c.Value(index) == 0 && len(c.Cell(index)) < len(c.Cell(placement.Index)) {
+			t.Errorf("expected the cell with the fewest candidates, but cell %d has fewer than cell %d",
+				index, placement.Index)
+		}
+	}
+	if exp := []int{placement.Index}; !reflect.DeepEqual(exp, got.Cells()) {
+		t.Errorf("expected cells %v, got %v", exp, got.Cells())
+	}
+}
+
+func TestPuzzle_HintWith(t *testing.T) {
+	// the first row only needs 4, but is empty in the givens.
+	p, err := NewPuzzle([]int{
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	got, err := p.HintWith([]int{
+		1, 2, 3, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if exp := []Candidate{{Index: 3, Value: 4}}; got.Technique != HiddenSingle || !reflect.DeepEqual(exp, got.Placements) {
+		t.Errorf("expected a hidden single placing %v, got %v", exp, got)
+	}
+}
+
+func TestPuzzle_Hint_AfterSolve(t *testing.T) {
+	p, err := NewPuzzle(hardPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	exp, err := p.Hint()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+
+	// neither a cancelled nor a finished solve should change the hint.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.SolveContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+	if got, err := p.Hint(); err != nil || !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v after a cancelled solve, got %v, %v", exp, got, err)
+	}
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+	if got, err := p.Hint(); err != nil || !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v after solving, got %v, %v", exp, got, err)
+	}
+}
+
+func TestPuzzle_Hint_Solved(t *testing.T) {
+	p, err := NewPuzzle(make([]int, 16))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if _, err := p.HintWith(solve(t, make([]int, 16))); !errors.Is(err, ErrPuzzleSolved) {
+		t.Errorf("expected error %v, got %v", ErrPuzzleSolved, err)
+	}
+}
+
+func TestPuzzle_Hint_SolvedGivens(t *testing.T) {
+	p, err := NewPuzzle(solve(t, make([]int, 16)))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if _, err := p.Hint(); !errors.Is(err, ErrPuzzleSolved) {
+		t.Errorf("expected error %v, got %v", ErrPuzzleSolved, err)
+	}
+}
+
+func TestPuzzle_Hint_NoSolution(t *testing.T) {
+	// the third cell of the first row can't hold 3 or 4, which are already in its column.
+	p, err := NewPuzzle([]int{
+		1, 2, 0, 0,
+		0, 0, 3, 0,
+		0, 0, 4, 0,
+		0, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if _, err := p.Hint(); !errors.Is(err, ErrMissingIteration) {
+		t.Errorf("expected error %v, got %v", ErrMissingIteration, err)
+	}
+}
+
+func TestStep_Cells(t *testing.T) {
+	s := &Step{
+		Placements:   []Candidate{{Index: 4, Value: 1}},
+		Eliminations: []Candidate{{Index: 2, Value: 1}, {Index: 3, Value: 2}, {Index: 2, Value: 3}},
+	}
+	if exp, got := []int{4, 2, 3}, s.Cells(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
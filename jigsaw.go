@@ -0,0 +1,80 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrInvalidRegionMap is returned when a region map does not split a puzzle into valid sections.
+var ErrInvalidRegionMap = errors.New("invalid region map")
+
+// SectionsFromRegionMap returns the sections described by the given region map, as used by jigsaw puzzles.
+//
+// The region map holds a region ID for every cell in the puzzle, in the same order as the puzzle items.
+// IDs can be any integers, and sections are returned in ascending order of ID.
+// An error wrapping ErrInvalidRegionMap is returned unless there are exactly puzzleSize regions,
+// each containing puzzleSize cells that are connected horizontally or vertically.
+func SectionsFromRegionMap(puzzleSize int, regionMap []int) ([]*Region, error) {
+	if len(regionMap) != puzzleSize*puzzleSize {
+		return nil, fmt.Errorf("%w: expected %d cells, got %d", ErrInvalidRegionMap, puzzleSize*puzzleSize, len(regionMap))
+	}
+
+	cellsByID := make(map[int][]int)
+	for index, id := range regionMap {
+		cellsByID[id] = append(cellsByID[id], index)
+	}
+	if len(cellsByID) != puzzleSize {
+		return nil, fmt.Errorf("%w: expected %d regions, got %d", ErrInvalidRegionMap, puzzleSize, len(cellsByID))
+	}
+	ids := make([]int, 0, len(cellsByID))
+	for id := range cellsByID {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	sections := make([]*Region, len(ids))
+	for i, id := range ids {
+		cells := cellsByID[id]
+		if len(cells) != puzzleSize {
+			return nil, fmt.Errorf("%w: region %d has %d cells, expected %d", ErrInvalidRegionMap, id, len(cells), puzzleSize)
+		}
+		if !connected(regionMap, puzzleSize, cells[0]) {
+			return nil, fmt.Errorf("%w: region %d is not connected", ErrInvalidRegionMap, id)
+		}
+		sections[i] = &Region{Kind: "section", Indexes: cells}
+	}
+	return sections, nil
+}
+
+// connected returns true if every cell with the same region ID as the cell at start can be reached from it
+// by moving horizontally or vertically between cells of that region.
+func connected(regionMap []int, puzzleSize int, start int) bool {
+	id := regionMap[start]
+	seen := map[int]bool{start: true}
+	stack := []int{start}
+	for len(stack) > 0 {
+		index := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		row, column := getRowFromIndex(index, puzzleSize), getColumnFromIndex(index, puzzleSize)
+		neighbours := [][2]int{{row - 1, column}, {row + 1, column}, {row, column - 1}, {row, column + 1}}
+		for _, n := range neighbours {
+			if n[0] < 0 || n[0] >= puzzleSize || n[1] < 0 || n[1] >= puzzleSize {
+				continue
+			}
+			next := n[0]*puzzleSize + n[1]
+			if regionMap[next] == id && !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	size := 0
+	for _, other := range regionMap {
+		if other == id {
+			size++
+		}
+	}
+	return len(seen) == size
+}
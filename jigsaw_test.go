@@ -0,0 +1,194 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var jigsawRegionMap = []int{
+	0, 0, 0, 0, 1, 1, 2, 2, 2,
+	0, 0, 1, 1, 1, 1, 1, 2, 2,
+	3, 0, 0, 1, 1, 4, 2, 2, 2,
+	3, 0, 4, 4, 4, 4, 2, 5, 5,
+	3, 3, 3, 3, 4, 5, 5, 5, 5,
+	3, 6, 6, 4, 4, 7, 5, 5, 8,
+	3, 6, 6, 7, 4, 7, 5, 8, 8,
+	3, 6, 6, 7, 7, 7, 8, 8, 8,
+	6, 6, 6, 7, 7, 7, 8, 8, 8,
+}
+
+func TestSectionsFromRegionMap(t *testing.T) {
+	got, err := SectionsFromRegionMap(4, []int{
+		// ids don't have to start at 0 or be consecutive.
+		5, 5, 5, 7,
+		5, 7, 7, 7,
+		2, 2, 9, 9,
+		2, 2, 9, 9,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	exp := []*Region{
+		{Kind: "section", Indexes: []int{8, 9, 12, 13}},
+		{Kind: "section", Indexes: []int{0, 1, 2, 4}},
+		{Kind: "section", Indexes: []int{3, 5, 6, 7}},
+		{Kind: "section", Indexes: []int{10, 11, 14, 15}},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestSectionsFromRegionMap_Invalid(t *testing.T) {
+	tests := []struct {
+		Name      string
+		RegionMap []int
+	}{
+		{Name: "WrongLength", RegionMap: []int{0, 0, 1, 1}},
+		{Name: "TooFewRegions", RegionMap: []int{
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+			1, 1, 1, 1,
+			1, 1, 1, 1,
+		}},
+		{Name: "WrongRegionSize", RegionMap: []int{
+			0, 0, 0, 1,
+			0, 0, 1, 1,
+			2, 2, 3, 3,
+			2, 2, 3, 3,
+		}},
+		{Name: "Disconnected", RegionMap: []int{
+			0, 0, 1, 0,
+			0, 1, 1, 1,
+			2, 2, 3, 3,
+			2, 2, 3, 3,
+		}},
+		{Name: "DiagonalOnly", RegionMap: []int{
+			0, 1, 1, 1,
+			1, 0, 2, 2,
+			3, 3, 0, 2,
+			3, 3, 2, 0,
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := SectionsFromRegionMap(4, tc.RegionMap)
+			if !errors.Is(err, ErrInvalidRegionMap) {
+				t.Errorf("expected error %v, got %v", ErrInvalidRegionMap, err)
+			}
+			if _, err := NewPuzzle(make([]int, 16), WithRegionMap(tc.RegionMap)); !errors.Is(err, ErrInvalidRegionMap) {
+				t.Errorf("expected NewPuzzle error %v, got %v", ErrInvalidRegionMap, err)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Solve_Jigsaw(t *testing.T) {
+	tests := []struct {
+		Name      string
+		RegionMap []int
+		In        []int
+		Exp       []int
+	}{
+		{
+			Name:      "9x9",
+			RegionMap: jigsawRegionMap,
+			In: []int{
+				0, 0, 0, 0, 5, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 8, 0, 0, 1,
+				0, 0, 0, 0, 0, 0, 0, 0, 0,
+				7, 0, 0, 0, 0, 0, 2, 0, 0,
+				2, 0, 0, 9, 0, 0, 0, 3, 0,
+				4, 0, 0, 0, 8, 0, 0, 0, 0,
+				5, 0, 2, 0, 0, 0, 0, 0, 0,
+				0, 0, 5, 0, 0, 1, 4, 0, 0,
+				9, 7, 0, 0, 0, 0, 1, 0, 0,
+			},
+			Exp: []int{
+				1, 2, 3, 4, 5, 6, 8, 7, 9,
+				6, 9, 4, 2, 3, 8, 7, 5, 1,
+				8, 5, 7, 1, 9, 2, 3, 4, 6,
+				7, 8, 9, 3, 6, 5, 2, 1, 4,
+				2, 1, 6, 9, 4, 7, 5, 3, 8,
+				4, 3, 1, 7, 8, 9, 6, 2, 5,
+				5, 4, 2, 6, 1, 3, 9, 8, 7,
+				3, 6, 5, 8, 7, 1, 4, 9, 2,
+				9, 7, 8, 5, 2, 4, 1, 6, 3,
+			},
+		},
+		{
+			// 5 has no square sections so can only be solved as a jigsaw.
+			Name: "5x5",
+			RegionMap: []int{
+				0, 0, 0, 1, 1,
+				0, 2, 1, 1, 1,
+				0, 2, 2, 2, 2,
+				3, 4, 4, 4, 4,
+				3, 3, 3, 3, 4,
+			},
+			In: []int{
+				0, 2, 0, 0, 0,
+				0, 4, 1, 0, 0,
+				0, 0, 0, 0, 0,
+				0, 0, 0, 2, 0,
+				0, 0, 3, 0, 0,
+			},
+			Exp: []int{
+				1, 2, 5, 3, 4,
+				3, 4, 1, 5, 2,
+				4, 5, 2, 1, 3,
+				5, 3, 4, 2, 1,
+				2, 1, 3, 4, 5,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			t.Run(tc.Name+"/"+config.Name, func(t *testing.T) {
+				p, err := NewPuzzle(tc.In, append(config.Options, WithRegionMap(tc.RegionMap))...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				if err := p.Solve(); err != nil {
+					t.Errorf("could not solve puzzle: %s", err)
+					return
+				}
+				got, err := p.Result()
+				if err != nil {
+					t.Errorf("could not get result: %s", err)
+					return
+				}
+				if !reflect.DeepEqual(tc.Exp, got) {
+					t.Errorf("expected %v, got %v", tc.Exp, got)
+				}
+			})
+		}
+	}
+}
+
+func TestNewPuzzle_Jigsaw_Conflict(t *testing.T) {
+	in := make([]int, 81)
+	// cells 3 and 19 are in the first jigsaw section but not the same square section.
+	in[3] = 4
+	in[19] = 4
+	_, err := NewPuzzle(in, WithRegionMap(jigsawRegionMap))
+	var got *ConflictError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConflictError, got %v", err)
+		return
+	}
+	exp := &ConflictError{
+		Value: 4,
+		Unit:  "section",
+		Cell:  CellPosition{Index: 19, Row: 2, Column: 1, Section: 0},
+		Other: CellPosition{Index: 3, Row: 0, Column: 3, Section: 0},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
@@ -0,0 +1,143 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPuzzle_Solve_LatinSquare(t *testing.T) {
+	// 7 has no rectangular sections so can only be solved as a Latin square or jigsaw.
+	in := []int{
+		0, 6, 0, 1, 2, 0, 0,
+		3, 0, 5, 0, 4, 0, 0,
+		0, 5, 0, 0, 0, 0, 0,
+		2, 1, 0, 0, 0, 5, 0,
+		0, 0, 0, 0, 7, 0, 4,
+		0, 0, 0, 0, 0, 7, 0,
+		7, 2, 0, 0, 6, 0, 1,
+	}
+	exp := []int{
+		4, 6, 7, 1, 2, 3, 5,
+		3, 7, 5, 6, 4, 1, 2,
+		6, 5, 4, 7, 1, 2, 3,
+		2, 1, 6, 4, 3, 5, 7,
+		5, 3, 1, 2, 7, 6, 4,
+		1, 4, 2, 3, 5, 7, 6,
+		7, 2, 3, 5, 6, 4, 1,
+	}
+
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(in, append(config.Options, WithLatinSquare())...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+		})
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		p, err := NewPuzzle(in, WithLatinSquare())
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if _, err := p.HasUniqueSolution(); err != nil {
+			t.Errorf("expected a unique solution, got %s", err)
+		}
+	})
+}
+
+func TestPuzzle_Solve_LatinSquare_Empty(t *testing.T) {
+	// solving an empty puzzle generates a Latin square of any size.
+	for _, size := range []int{1, 2, 5, 10} {
+		for _, config := range solveConfigs {
+			t.Run(fmt.Sprintf("%d/%s", size, config.Name), func(t *testing.T) {
+				p, err := NewPuzzle(make([]int, size*size), append(config.Options, WithLatinSquare())...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				if err := p.Solve(); err != nil {
+					t.Errorf("could not solve puzzle: %s", err)
+					return
+				}
+				got, err := p.Result()
+				if err != nil {
+					t.Errorf("could not get result: %s", err)
+					return
+				}
+				for _, r := range append(Rows(size), Columns(size)...) {
+					seen := make(map[int]bool, size)
+					for _, index := range r.Indexes {
+						value := got[index]
+						if value < 1 || value > size || seen[value] {
+							t.Errorf("%v does not hold every value once: %v", r, got)
+							return
+						}
+						seen[value] = true
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestNewPuzzle_LatinSquare_NoSections(t *testing.T) {
+	// a Latin square that breaks the sections of a 4x4 sudoku.
+	in := []int{
+		1, 2, 3, 4,
+		2, 3, 4, 1,
+		3, 4, 1, 2,
+		4, 1, 2, 3,
+	}
+	var conflict *ConflictError
+	if _, err := NewPuzzle(in); !errors.As(err, &conflict) || conflict.Unit != "section" {
+		t.Errorf("expected section *ConflictError, got %v", err)
+	}
+	p, err := NewPuzzle(in, WithLatinSquare(), WithSectionSize(2, 2))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	for _, c := range p.Constraints() {
+		if r, ok := c.(*Region); ok && r.Kind == "section" {
+			t.Errorf("expected no sections, got %v", r)
+		}
+	}
+}
+
+func TestNewPuzzle_LatinSquare_Conflict(t *testing.T) {
+	in := make([]int, 49)
+	in[3] = 5
+	in[38] = 5
+	_, err := NewPuzzle(in, WithLatinSquare())
+	var got *ConflictError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConflictError, got %v", err)
+		return
+	}
+	exp := &ConflictError{
+		Value: 5,
+		Unit:  "column",
+		Cell:  CellPosition{Index: 38, Row: 5, Column: 3, Section: -1},
+		Other: CellPosition{Index: 3, Row: 0, Column: 3, Section: -1},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
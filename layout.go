@@ -0,0 +1,57 @@
+package sudoku
+
+// layout describes the shape of a puzzle: the regions and constraints covering its cells,
+// and where each cell is positioned.
+// A layout is never modified once created, so it can be shared between puzzles.
+type layout struct {
+	puzzleSize  int
+	rows        []*Region
+	columns     []*Region
+	sections    []*Region
+	constraints []Constraint
+	// positions holds the position of each cell, used to describe the cell in errors.
+	positions []CellPosition
+}
+
+// squareLayout returns the layout of a single square puzzle of the given size.
+func squareLayout(puzzleSize int, sections []*Region, constraints []Constraint) *layout {
+	l := &layout{
+		puzzleSize:  puzzleSize,
+		rows:        Rows(puzzleSize),
+		columns:     Columns(puzzleSize),
+		sections:    sections,
+		constraints: constraints,
+		positions:   make([]CellPosition, puzzleSize*puzzleSize),
+	}
+	for index := range l.positions {
+		l.positions[index] = CellPosition{
+			Index:   index,
+			Row:     getRowFromIndex(index, puzzleSize),
+			Column:  getColumnFromIndex(index, puzzleSize),
+			Section: -1,
+		}
+	}
+	l.setSections()
+	return l
+}
+
+// setSections sets the section of every cell position from the sections of the layout.
+// Cells in more than one section are given the last one.
+func (l *layout) setSections() {
+	for i, s := range l.sections {
+		for _, index := range s.Indexes {
+			l.positions[index].Section = i
+		}
+	}
+}
+
+// cellCount returns the number of cells in the layout.
+func (l *layout) cellCount() int {
+	return len(l.positions)
+}
+
+// square returns true if the layout is a single square grid, where the cell in a given row and column
+// has the index row*puzzleSize+column.
+func (l *layout) square() bool {
+	return l.cellCount() == l.puzzleSize*l.puzzleSize && len(l.rows) == l.puzzleSize && len(l.columns) == l.puzzleSize
+}
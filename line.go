@@ -0,0 +1,136 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Thermometer is a constraint requiring the values along a line to strictly increase from the bulb,
+// which is the first cell of the line.
+//
+// Candidates are pruned using the number of cells between each pair of cells on the line,
+// so the third cell of a thermometer can never hold 1 or 2.
+type Thermometer struct {
+	Indexes []int
+}
+
+// Cells returns the indexes of the cells on the thermometer, starting with the bulb.
+func (t *Thermometer) Cells() []int {
+	return t.Indexes
+}
+
+// Allowed returns true if the value leaves room for strictly increasing values in the cells before and after it,
+// given the values already placed on the thermometer.
+func (t *Thermometer) Allowed(values Values, index int, value int) bool {
+	position := -1
+	for i, cellIndex := range t.Indexes {
+		if cellIndex == index {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return true
+	}
+	if value <= position || value > values.Size()-(len(t.Indexes)-1-position) {
+		return false
+	}
+	for i, cellIndex := range t.Indexes {
+		v := values.Value(cellIndex)
+		if i == position || v == 0 {
+			continue
+		}
+		// cells further along the line need a value at least one larger for every step between them.
+		if i < position && value-v < position-i {
+			return false
+		}
+		if i > position && v-value < i-position {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a description of the thermometer.
+func (t *Thermometer) String() string {
+	return fmt.Sprintf("thermometer %v", t.Indexes)
+}
+
+// validate returns an error if the thermometer covers a cell more than once,
+// or is too long to hold strictly increasing values in a puzzle of the given size.
+func (t *Thermometer) validate(puzzleSize int) error {
+	if len(t.Indexes) > puzzleSize {
+		return fmt.Errorf("%w: %v covers %d cells but there are only %d values",
+			ErrInvalidConstraint, t, len(t.Indexes), puzzleSize)
+	}
+	return validateLine(t, t.Indexes)
+}
+
+// Arrow is a constraint requiring the values along a line to add up to the value in the circle at the end of the arrow.
+// Values can be repeated along the arrow unless another constraint prevents it.
+type Arrow struct {
+	// Circle is the index of the cell holding the sum.
+	Circle int
+	// Indexes are the indexes of the cells on the arrow, not including the circle.
+	Indexes []int
+}
+
+// Cells returns the index of the circle followed by the indexes of the cells on the arrow.
+func (a *Arrow) Cells() []int {
+	return append([]int{a.Circle}, a.Indexes...)
+}
+
+// Allowed returns true if the cells on the arrow can still add up to the value in the circle.
+// Each empty cell adds at least 1 and at most the puzzle size to the sum, and an empty circle
+// can hold at most the puzzle size.
+func (a *Arrow) Allowed(values Values, index int, value int) bool {
+	circle := values.Value(a.Circle)
+	if index == a.Circle {
+		circle = value
+	}
+	sum, empty := 0, 0
+	for _, cellIndex := range a.Indexes {
+		v := values.Value(cellIndex)
+		if cellIndex == index {
+			v = value
+		}
+		if v == 0 {
+			empty++
+			continue
+		}
+		sum += v
+	}
+	if circle == 0 {
+		return sum+empty <= values.Size()
+	}
+	return sum+empty <= circle && circle <= sum+empty*values.Size()
+}
+
+// String returns a description of the arrow.
+func (a *Arrow) String() string {
+	return fmt.Sprintf("arrow from %d along %v", a.Circle, a.Indexes)
+}
+
+// validate returns an error if the arrow has no cells, covers a cell more than once,
+// or is too long for the smallest sum of its cells to fit in the circle of a puzzle of the given size.
+func (a *Arrow) validate(puzzleSize int) error {
+	if len(a.Indexes) == 0 {
+		return fmt.Errorf("%w: %v has no cells", ErrInvalidConstraint, a)
+	}
+	if len(a.Indexes) > puzzleSize {
+		return fmt.Errorf("%w: %v covers %d cells so cannot add up to %d or less",
+			ErrInvalidConstraint, a, len(a.Indexes), puzzleSize)
+	}
+	return validateLine(a, a.Cells())
+}
+
+// validateLine returns an error if the given cells of a line constraint contain a cell more than once.
+func validateLine(c Constraint, indexes []int) error {
+	seen := make(map[int]bool, len(indexes))
+	for _, index := range indexes {
+		if seen[index] {
+			return fmt.Errorf("%w: %v contains cell %d more than once", ErrInvalidConstraint, c, index)
+		}
+		seen[index] = true
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// lineThermometers and lineArrows are drawn on the solution of TestPuzzle_Solve_Diagonals.
+var lineThermometers = []*Thermometer{
+	{Indexes: []int{12, 13, 14, 15}},
+	{Indexes: []int{20, 29, 28, 37, 38, 47}},
+	{Indexes: []int{34, 25, 26, 35}},
+	{Indexes: []int{41, 42, 33, 32}},
+	{Indexes: []int{66, 57, 58}},
+}
+
+var lineArrows = []*Arrow{
+	{Circle: 0, Indexes: []int{1, 2}},
+	{Circle: 7, Indexes: []int{6, 5}},
+	{Circle: 21, Indexes: []int{30, 39}},
+	{Circle: 27, Indexes: []int{36, 45}},
+}
+
+func TestThermometer_Allowed(t *testing.T) {
+	thermometer := &Thermometer{Indexes: []int{0, 1, 2}}
+	tests := []struct {
+		Name   string
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		{Name: "Empty", Values: make([]int, 81), Index: 1, Value: 5, Exp: true},
+		{Name: "NoRoomBefore", Values: make([]int, 81), Index: 2, Value: 2, Exp: false},
+		{Name: "NoRoomAfter", Values: make([]int, 81), Index: 0, Value: 8, Exp: false},
+		{Name: "LargestValue", Values: make([]int, 81), Index: 2, Value: 9, Exp: true},
+		{Name: "Increasing", Values: []int{2, 0, 7}, Index: 1, Value: 5, Exp: true},
+		{Name: "NotIncreasing", Values: []int{2, 0, 7}, Index: 1, Value: 7, Exp: false},
+		{Name: "Equal", Values: []int{2, 0, 0}, Index: 1, Value: 2, Exp: false},
+		// the middle cell needs a value between 4 and 5.
+		{Name: "NoRoomBetween", Values: []int{4, 0, 0}, Index: 2, Value: 5, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			values := make(valueSlice, 81)
+			copy(values, tc.Values)
+			if got := thermometer.Allowed(values, tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestArrow_Allowed(t *testing.T) {
+	arrow := &Arrow{Circle: 0, Indexes: []int{1, 2}}
+	tests := []struct {
+		Name   string
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		{Name: "Empty", Values: nil, Index: 1, Value: 5, Exp: true},
+		// the other cell on the arrow adds at least 1.
+		{Name: "TooLarge", Values: nil, Index: 1, Value: 9, Exp: false},
+		{Name: "CircleTooSmall", Values: nil, Index: 0, Value: 1, Exp: false},
+		{Name: "CircleSmallest", Values: nil, Index: 0, Value: 2, Exp: true},
+		{Name: "Sum", Values: []int{7, 3}, Index: 2, Value: 4, Exp: true},
+		{Name: "WrongSum", Values: []int{7, 3}, Index: 2, Value: 5, Exp: false},
+		{Name: "Repeated", Values: []int{6, 3}, Index: 2, Value: 3, Exp: true},
+		{Name: "CircleSum", Values: []int{0, 3, 4}, Index: 0, Value: 7, Exp: true},
+		{Name: "CircleWrongSum", Values: []int{0, 3, 4}, Index: 0, Value: 8, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			values := make(valueSlice, 81)
+			copy(values, tc.Values)
+			if got := arrow.Allowed(values, tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Solve_Lines(t *testing.T) {
+	// the givens of TestPuzzle_Solve_Diagonals, which only has a unique solution with the extra lines.
+	in := []int{
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 5, 0, 3, 7, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 2, 3, 0,
+		0, 0, 0, 6, 0, 0, 0, 1, 0,
+		2, 0, 0, 0, 0, 0, 4, 9, 0,
+		0, 0, 0, 7, 0, 0, 0, 0, 2,
+		0, 1, 0, 0, 0, 8, 7, 0, 5,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 9, 0, 0, 0, 0, 1, 0, 0,
+	}
+	opts := []Option{WithThermometers(lineThermometers...), WithArrows(lineArrows...)}
+
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(in, append(config.Options, opts...)...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(edgeSolution, got) {
+				t.Errorf("expected %v, got %v", edgeSolution, got)
+			}
+		})
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		p, err := NewPuzzle(in, opts...)
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, err := p.HasUniqueSolution(); !unique {
+			t.Errorf("expected puzzle to be unique, got %v", err)
+		}
+	})
+}
+
+func TestNewPuzzle_Lines_Invalid(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Option Option
+	}{
+		{Name: "LongThermometer", Option: WithThermometers(&Thermometer{Indexes: []int{0, 1, 2, 3, 7}})},
+		{Name: "RepeatedThermometerCell", Option: WithThermometers(&Thermometer{Indexes: []int{0, 1, 0}})},
+		{Name: "EmptyArrow", Option: WithArrows(&Arrow{Circle: 0})},
+		{Name: "LongArrow", Option: WithArrows(&Arrow{Circle: 0, Indexes: []int{1, 2, 3, 7, 6}})},
+		{Name: "CircleOnArrow", Option: WithArrows(&Arrow{Circle: 0, Indexes: []int{1, 0}})},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(make([]int, 16), tc.Option)
+			if !errors.Is(err, ErrInvalidConstraint) {
+				t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_Lines_ConstraintError(t *testing.T) {
+	thermometer := &Thermometer{Indexes: []int{0, 1, 2}}
+	_, err := NewPuzzle([]int{
+		3, 2, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	}, WithThermometers(thermometer))
+	var got *ConstraintError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConstraintError, got %v", err)
+		return
+	}
+	if got.Index != 0 || got.Constraint != thermometer {
+		t.Errorf("unexpected constraint error: %+v", got)
+	}
+}
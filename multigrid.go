@@ -0,0 +1,385 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// GridPosition is the position of the top left cell of one grid within a multi-grid puzzle.
+// Both values are zero based.
+type GridPosition struct {
+	Row    int
+	Column int
+}
+
+// SamuraiGrids are the positions of the five 9x9 grids of a Samurai puzzle within a 21x21 canvas.
+// The centre grid shares each of its corner sections with one of the other grids.
+var SamuraiGrids = []GridPosition{
+	{Row: 0, Column: 0},
+	{Row: 0, Column: 12},
+	{Row: 6, Column: 6},
+	{Row: 12, Column: 0},
+	{Row: 12, Column: 12},
+}
+
+// MultiPuzzle is a puzzle made up of several square grids that overlap and share some of their cells,
+// such as a Samurai puzzle. Every grid is solved at the same time.
+//
+// The grids are laid out on a rectangular canvas. Values are given and returned for every cell of the canvas,
+// row by row, with cells that are not in any grid holding 0.
+type MultiPuzzle struct {
+	puzzle   *Puzzle
+	width    int
+	gridSize int
+	grids    []GridPosition
+	// canvasIndexes holds the canvas index of each cell in the puzzle, and cellIndexes holds the cell index
+	// of each canvas cell, or -1 if the canvas cell is not in any grid.
+	canvasIndexes []int
+	cellIndexes   []int
+}
+
+// NewSamuraiPuzzle returns a new Samurai puzzle made up of five overlapping 9x9 grids.
+// The items are the values of a 21x21 canvas, see SamuraiGrids.
+func NewSamuraiPuzzle(items []int, opts ...Option) (*MultiPuzzle, error) {
+	return NewMultiPuzzle(items, 21, 9, SamuraiGrids, opts...)
+}
+
+// NewMultiPuzzle returns a new puzzle made up of square grids of gridSize cells, positioned on a canvas
+// that is width cells wide. The items are the values of every cell of the canvas, row by row.
+//
+// Options apply to every grid, so WithSectionSize, WithRegionMap, WithLatinSquare, WithDiagonals, WithWindows,
+// WithAntiKnight, WithAntiKing and WithNonConsecutive change the sections and extra rules of each grid.
+// Constraints given using WithConstraints, WithRegions, WithCages, WithEdges, WithOdd or WithEven use canvas indexes.
+// Outside clues given using WithSandwiches or WithSkyscrapers are not supported, and ErrInvalidConstraint is returned.
+// The same errors are returned as NewPuzzle. Cells that are not in any grid must hold 0, otherwise
+// an *InvalidValueError with a Max of 0 is returned.
+func NewMultiPuzzle(items []int, width int, gridSize int, grids []GridPosition, opts ...Option) (*MultiPuzzle, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if width <= 0 || len(items) == 0 || len(items)%width != 0 {
+		return nil, fmt.Errorf("%w: %d items do not make a canvas %d cells wide", ErrInvalidPuzzleSize, len(items), width)
+	}
+	if gridSize <= 0 || gridSize > maxPuzzleSize {
+		return nil, fmt.Errorf("%w: grids of size %d are not supported", ErrInvalidPuzzleSize, gridSize)
+	}
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("%w: a multi-grid puzzle needs at least one grid", ErrInvalidPuzzleSize)
+	}
+	height := len(items) / width
+	for _, pos := range grids {
+		if pos.Row < 0 || pos.Column < 0 || pos.Row+gridSize > height || pos.Column+gridSize > width {
+			return nil, fmt.Errorf("%w: grid at row %d, column %d does not fit within a %dx%d canvas",
+				ErrInvalidPuzzleSize, pos.Row, pos.Column, width, height)
+		}
+	}
+
+	p := &MultiPuzzle{
+		width:       width,
+		gridSize:    gridSize,
+		grids:       grids,
+		cellIndexes: make([]int, len(items)),
+	}
+	for i := range p.cellIndexes {
+		p.cellIndexes[i] = -1
+	}
+	for _, pos := range grids {
+		for local := 0; local < gridSize*gridSize; local++ {
+			canvasIndex := p.canvasIndex(pos, local)
+			if p.cellIndexes[canvasIndex] < 0 {
+				p.cellIndexes[canvasIndex] = len(p.canvasIndexes)
+				p.canvasIndexes = append(p.canvasIndexes, canvasIndex)
+			}
+		}
+	}
+
+	cellItems := make([]int, len(p.canvasIndexes))
+	for canvasIndex, value := range items {
+		cellIndex := p.cellIndexes[canvasIndex]
+		if cellIndex >= 0 {
+			cellItems[cellIndex] = value
+			continue
+		}
+		if value != 0 {
+			return nil, &InvalidValueError{
+				CellPosition: CellPosition{
+					Index:   canvasIndex,
+					Row:     getRowFromIndex(canvasIndex, width),
+					Column:  getColumnFromIndex(canvasIndex, width),
+					Section: -1,
+				},
+				Value: value,
+				Max:   0,
+			}
+		}
+	}
+
+	if len(o.sandwiches) > 0 || len(o.skyscrapers) > 0 {
+		return nil, fmt.Errorf("%w: outside clues can only be used with a single grid", ErrInvalidConstraint)
+	}
+	l, err := p.layout(o)
+	if err != nil {
+		return nil, err
+	}
+	p.puzzle, err = newPuzzle(cellItems, l, o)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// canvasIndex returns the canvas index of the cell at the given index within the grid at the given position.
+func (p *MultiPuzzle) canvasIndex(pos GridPosition, local int) int {
+	row := pos.Row + getRowFromIndex(local, p.gridSize)
+	column := pos.Column + getColumnFromIndex(local, p.gridSize)
+	return row*p.width + column
+}
+
+// layout returns the layout of every grid in the puzzle combined.
+// Regions that are shared by more than one grid are only included once.
+func (p *MultiPuzzle) layout(o *options) (*layout, error) {
+	sections, err := o.sections(nil, p.gridSize)
+	if err != nil {
+		return nil, err
+	}
+	extraRegions, err := o.extraRegions(nil, p.gridSize)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &layout{
+		puzzleSize: p.gridSize,
+		positions:  make([]CellPosition, len(p.canvasIndexes)),
+	}
+	for cellIndex, canvasIndex := range p.canvasIndexes {
+		l.positions[cellIndex] = CellPosition{
+			Index:   canvasIndex,
+			Row:     getRowFromIndex(canvasIndex, p.width),
+			Column:  getColumnFromIndex(canvasIndex, p.width),
+			Section: -1,
+		}
+	}
+
+	seen := make(map[string]bool)
+	// add converts regions within the grid at the given position to regions of cell indexes.
+	add := func(pos GridPosition, regions []*Region) []*Region {
+		res := make([]*Region, 0, len(regions))
+		for _, r := range regions {
+			converted := &Region{Kind: r.Kind, Indexes: make([]int, len(r.Indexes))}
+			for i, local := range r.Indexes {
+				converted.Indexes[i] = p.cellIndexes[p.canvasIndex(pos, local)]
+			}
+			sorted := append([]int(nil), converted.Indexes...)
+			sort.Ints(sorted)
+			key := fmt.Sprint(sorted)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			res = append(res, converted)
+		}
+		return res
+	}
+	for _, pos := range p.grids {
+		l.rows = append(l.rows, add(pos, Rows(p.gridSize))...)
+		l.columns = append(l.columns, add(pos, Columns(p.gridSize))...)
+		l.sections = append(l.sections, add(pos, sections)...)
+		for _, r := range add(pos, extraRegions) {
+			l.constraints = append(l.constraints, r)
+		}
+		if o.nonConsecutive {
+			for _, e := range NonConsecutiveEdges(p.gridSize) {
+				converted := &Edge{
+					Relation: e.Relation,
+					A:        p.cellIndexes[p.canvasIndex(pos, e.A)],
+					B:        p.cellIndexes[p.canvasIndex(pos, e.B)],
+				}
+				key := fmt.Sprint(converted)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				l.constraints = append(l.constraints, converted)
+			}
+		}
+	}
+	l.setSections()
+
+	for _, c := range append(o.parityConstraints(p.gridSize), o.constraints...) {
+		converted, err := p.convertConstraint(c)
+		if err != nil {
+			return nil, err
+		}
+		l.constraints = append(l.constraints, converted)
+	}
+	return l, nil
+}
+
+// convertConstraint converts a constraint using canvas indexes to one using cell indexes.
+func (p *MultiPuzzle) convertConstraint(c Constraint) (Constraint, error) {
+	if c == nil {
+		return nil, fmt.Errorf("%w: constraint is nil", ErrInvalidConstraint)
+	}
+	cells := make([]int, len(c.Cells()))
+	for i, canvasIndex := range c.Cells() {
+		if canvasIndex < 0 || canvasIndex >= len(p.cellIndexes) || p.cellIndexes[canvasIndex] < 0 {
+			return nil, fmt.Errorf("%w: %v covers cell %d which is not in any grid", ErrInvalidConstraint, c, canvasIndex)
+		}
+		cells[i] = p.cellIndexes[canvasIndex]
+	}
+	if r, ok := c.(*Region); ok {
+		return &Region{Kind: r.Kind, Indexes: cells}, nil
+	}
+	if a, ok := c.(*AllowedValues); ok {
+		return &AllowedValues{Kind: a.Kind, Indexes: cells, Values: a.Values}, nil
+	}
+	return &canvasConstraint{constraint: c, puzzle: p, cells: cells}, nil
+}
+
+// canvasConstraint wraps a constraint that uses canvas indexes so it can be used with cell indexes.
+type canvasConstraint struct {
+	constraint Constraint
+	puzzle     *MultiPuzzle
+	cells      []int
+}
+
+// Cells returns the cell indexes of the cells the constraint covers.
+func (c *canvasConstraint) Cells() []int {
+	return c.cells
+}
+
+// Allowed checks the wrapped constraint using canvas indexes.
+func (c *canvasConstraint) Allowed(values Values, index int, value int) bool {
+	return c.constraint.Allowed(canvasValues{values: values, puzzle: c.puzzle}, c.puzzle.canvasIndexes[index], value)
+}
+
+// String returns a description of the wrapped constraint.
+func (c *canvasConstraint) String() string {
+	return fmt.Sprint(c.constraint)
+}
+
+// validate validates the wrapped constraint if it can be validated.
+func (c *canvasConstraint) validate(puzzleSize int) error {
+	if v, ok := c.constraint.(validator); ok {
+		return v.validate(puzzleSize)
+	}
+	return nil
+}
+
+// canvasValues reads cell values using canvas indexes.
+type canvasValues struct {
+	values Values
+	puzzle *MultiPuzzle
+}
+
+// Size returns the size of each grid.
+func (v canvasValues) Size() int {
+	return v.values.Size()
+}
+
+// Value returns the value at the given canvas index, or 0 if the canvas cell is not in any grid.
+func (v canvasValues) Value(index int) int {
+	cellIndex := v.puzzle.cellIndexes[index]
+	if cellIndex < 0 {
+		return 0
+	}
+	return v.values.Value(cellIndex)
+}
+
+// toCanvas converts the values of every cell to the values of every canvas cell.
+func (p *MultiPuzzle) toCanvas(items []int) []int {
+	res := make([]int, len(p.cellIndexes))
+	for cellIndex, canvasIndex := range p.canvasIndexes {
+		res[canvasIndex] = items[cellIndex]
+	}
+	return res
+}
+
+// Width returns the number of cells in each row of the canvas.
+func (p *MultiPuzzle) Width() int {
+	return p.width
+}
+
+// Grids returns the position of each grid within the canvas.
+func (p *MultiPuzzle) Grids() []GridPosition {
+	return p.grids
+}
+
+// InGrid returns true if the canvas cell at the given index is part of at least one grid.
+func (p *MultiPuzzle) InGrid(index int) bool {
+	return index >= 0 && index < len(p.cellIndexes) && p.cellIndexes[index] >= 0
+}
+
+// Solve solves every grid of the puzzle.
+func (p *MultiPuzzle) Solve() error {
+	return p.puzzle.Solve()
+}
+
+// SolveContext solves every grid of the puzzle, giving up if the given context is cancelled or its deadline is exceeded.
+func (p *MultiPuzzle) SolveContext(ctx context.Context) error {
+	return p.puzzle.SolveContext(ctx)
+}
+
+// CompletionRate returns stats on the completion rate of the puzzle.
+// Cells that are shared by more than one grid are only counted once.
+func (p *MultiPuzzle) CompletionRate() (*CompletionRate, error) {
+	return p.puzzle.CompletionRate()
+}
+
+// Canvas returns the current values of every cell of the canvas, row by row.
+func (p *MultiPuzzle) Canvas() ([]int, error) {
+	items, err := p.puzzle.Result()
+	if err != nil {
+		return nil, err
+	}
+	return p.toCanvas(items), nil
+}
+
+// Result returns the current values of each grid, in the same order as the grid positions.
+// The values of each grid are given row by row, so they can be passed to FormatPuzzle.
+func (p *MultiPuzzle) Result() ([][]int, error) {
+	canvas, err := p.Canvas()
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]int, len(p.grids))
+	for i, pos := range p.grids {
+		res[i] = make([]int, p.gridSize*p.gridSize)
+		for local := range res[i] {
+			res[i][local] = canvas[p.canvasIndex(pos, local)]
+		}
+	}
+	return res, nil
+}
+
+// CountSolutions returns the number of solutions the puzzle has, stopping once limit solutions have been found.
+// See Puzzle.CountSolutions.
+func (p *MultiPuzzle) CountSolutions(limit int) (int, error) {
+	return p.puzzle.CountSolutions(limit)
+}
+
+// HasUniqueSolution returns true if the puzzle has exactly one solution.
+// If it has more than one, the solutions in the returned *NotUniqueError are canvas values.
+// See Puzzle.HasUniqueSolution.
+func (p *MultiPuzzle) HasUniqueSolution() (bool, error) {
+	return p.HasUniqueSolutionContext(context.Background())
+}
+
+// HasUniqueSolutionContext returns true if the puzzle has exactly one solution,
+// stopping with an error if the given context is cancelled or its deadline is exceeded.
+// See HasUniqueSolution.
+func (p *MultiPuzzle) HasUniqueSolutionContext(ctx context.Context) (bool, error) {
+	unique, err := p.puzzle.HasUniqueSolutionContext(ctx)
+	var notUnique *NotUniqueError
+	if errors.As(err, &notUnique) {
+		return unique, &NotUniqueError{
+			First:  p.toCanvas(notUnique.First),
+			Second: p.toCanvas(notUnique.Second),
+		}
+	}
+	return unique, err
+}
@@ -0,0 +1,235 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// samuraiPuzzle is a Samurai puzzle on a 21x21 canvas, with 0 for cells outside of every grid.
+var samuraiPuzzle = []int{
+	5, 4, 0, 3, 0, 0, 0, 7, 9, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 7, 1,
+	0, 0, 9, 0, 0, 6, 3, 0, 0, 0, 0, 0, 7, 0, 0, 0, 5, 4, 9, 0, 0,
+	0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0, 5, 0,
+	0, 5, 0, 7, 0, 0, 0, 1, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 9, 0, 0, 0, 5, 0, 1, 0,
+	0, 0, 2, 0, 9, 0, 6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 0, 5,
+	0, 0, 0, 0, 0, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 1, 0, 0, 3, 0, 8,
+	0, 1, 0, 4, 0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 2, 0, 0, 0, 6,
+	0, 0, 3, 9, 0, 0, 0, 0, 5, 0, 0, 9, 3, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 9, 3, 2, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 2, 9, 0, 1, 0, 6, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 2, 0, 0, 1, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 5, 0, 0, 0, 0, 8,
+	0, 4, 0, 0, 0, 0, 0, 0, 0, 4, 6, 0, 0, 0, 0, 0, 7, 9, 0, 0, 6,
+	0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 6, 0, 0, 0, 2, 7, 0, 0, 0, 8, 0, 0, 0, 5, 4, 9, 0, 0,
+	0, 0, 2, 0, 0, 0, 5, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 7, 6, 0, 4,
+	9, 3, 0, 0, 6, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 8, 1,
+	0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 9, 0,
+	0, 0, 1, 0, 8, 7, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0,
+}
+
+var samuraiSolution = []int{
+	5, 4, 6, 3, 2, 1, 8, 7, 9, 0, 0, 0, 6, 5, 4, 9, 3, 2, 8, 7, 1,
+	8, 7, 9, 5, 4, 6, 3, 2, 1, 0, 0, 0, 7, 8, 1, 6, 5, 4, 9, 3, 2,
+	3, 2, 1, 8, 7, 9, 5, 4, 6, 0, 0, 0, 9, 3, 2, 8, 7, 1, 6, 5, 4,
+	6, 5, 4, 7, 3, 2, 9, 1, 8, 0, 0, 0, 4, 6, 5, 2, 1, 3, 7, 8, 9,
+	1, 9, 8, 6, 5, 4, 7, 3, 2, 0, 0, 0, 8, 9, 7, 4, 6, 5, 2, 1, 3,
+	7, 3, 2, 1, 9, 8, 6, 5, 4, 0, 0, 0, 2, 1, 3, 7, 8, 9, 4, 6, 5,
+	4, 6, 5, 2, 8, 3, 1, 9, 7, 3, 2, 8, 5, 4, 6, 1, 9, 7, 3, 2, 8,
+	9, 1, 7, 4, 6, 5, 2, 8, 3, 5, 4, 6, 1, 7, 9, 3, 2, 8, 5, 4, 6,
+	2, 8, 3, 9, 1, 7, 4, 6, 5, 1, 7, 9, 3, 2, 8, 5, 4, 6, 1, 9, 7,
+	0, 0, 0, 0, 0, 0, 5, 4, 6, 9, 3, 2, 8, 1, 7, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 7, 1, 8, 6, 5, 4, 9, 3, 2, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 3, 2, 9, 8, 1, 7, 6, 5, 4, 0, 0, 0, 0, 0, 0,
+	3, 2, 8, 7, 1, 9, 6, 5, 4, 2, 8, 3, 7, 9, 1, 5, 4, 6, 3, 2, 8,
+	5, 4, 6, 3, 2, 8, 9, 7, 1, 4, 6, 5, 2, 8, 3, 1, 7, 9, 5, 4, 6,
+	1, 9, 7, 5, 4, 6, 8, 3, 2, 7, 9, 1, 4, 6, 5, 3, 2, 8, 1, 7, 9,
+	6, 5, 3, 4, 7, 2, 1, 8, 9, 0, 0, 0, 5, 4, 6, 9, 3, 2, 8, 1, 7,
+	8, 1, 9, 6, 5, 3, 4, 2, 7, 0, 0, 0, 8, 1, 7, 6, 5, 4, 9, 3, 2,
+	4, 7, 2, 8, 9, 1, 5, 6, 3, 0, 0, 0, 3, 2, 9, 8, 1, 7, 6, 5, 4,
+	9, 3, 5, 2, 6, 4, 7, 1, 8, 0, 0, 0, 6, 5, 4, 2, 9, 3, 7, 8, 1,
+	7, 8, 4, 1, 3, 5, 2, 9, 6, 0, 0, 0, 1, 7, 8, 4, 6, 5, 2, 9, 3,
+	2, 6, 1, 9, 8, 7, 3, 4, 5, 0, 0, 0, 9, 3, 2, 7, 8, 1, 4, 6, 5,
+}
+
+func TestNewSamuraiPuzzle_Solve(t *testing.T) {
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewSamuraiPuzzle(samuraiPuzzle, config.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Canvas()
+			if err != nil {
+				t.Errorf("could not get canvas: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(samuraiSolution, got) {
+				t.Errorf("expected %v, got %v", samuraiSolution, got)
+			}
+
+			rate, err := p.CompletionRate()
+			if err != nil {
+				t.Errorf("could not get completion rate: %s", err)
+				return
+			}
+			// 5 grids of 81 cells, with the centre grid sharing 4 sections of 9 cells.
+			if exp := 5*81 - 4*9; rate.TotalCells != exp || !rate.Completed {
+				t.Errorf("expected %d cells to be completed, got %+v", exp, rate)
+			}
+		})
+	}
+}
+
+func TestMultiPuzzle_Result(t *testing.T) {
+	p, err := NewSamuraiPuzzle(samuraiPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+	grids, err := p.Result()
+	if err != nil {
+		t.Errorf("could not get result: %s", err)
+		return
+	}
+	if len(grids) != 5 {
+		t.Errorf("expected 5 grids, got %d", len(grids))
+		return
+	}
+
+	for i, pos := range SamuraiGrids {
+		givens := make([]int, 81)
+		for index := range givens {
+			givens[index] = samuraiPuzzle[(pos.Row+index/9)*21+pos.Column+index%9]
+		}
+		checkSolution(t, givens, grids[i])
+	}
+
+	// the bottom right section of the top left grid is the top left section of the centre grid.
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			corner := grids[0][(row+6)*9+column+6]
+			centre := grids[2][row*9+column]
+			if corner != centre {
+				t.Errorf("expected shared cell at row %d, column %d to match, got %d and %d", row, column, corner, centre)
+			}
+		}
+	}
+}
+
+func TestMultiPuzzle_HasUniqueSolution(t *testing.T) {
+	p, err := NewSamuraiPuzzle(samuraiPuzzle, WithAlgorithm(DancingLinksAlgorithm))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if unique, err := p.HasUniqueSolution(); !unique {
+		t.Errorf("expected puzzle to be unique, got %v", err)
+	}
+
+	// removing the givens of the centre grid leaves multiple solutions.
+	in := append([]int(nil), samuraiPuzzle...)
+	for _, index := range []int{10*21 + 12, 10*21 + 13, 10*21 + 14, 11*21 + 7, 11*21 + 8, 11*21 + 10, 11*21 + 12} {
+		in[index] = 0
+	}
+	p, err = NewSamuraiPuzzle(in, WithAlgorithm(DancingLinksAlgorithm))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	unique, err := p.HasUniqueSolution()
+	var notUnique *NotUniqueError
+	if unique || !errors.As(err, &notUnique) {
+		t.Errorf("expected *NotUniqueError, got %v", err)
+		return
+	}
+	if len(notUnique.First) != len(in) || len(notUnique.Second) != len(in) {
+		t.Errorf("expected solutions to be canvas values")
+	}
+}
+
+func TestMultiPuzzle_HasUniqueSolutionContext(t *testing.T) {
+	p, err := NewSamuraiPuzzle(samuraiPuzzle, WithAlgorithm(DancingLinksAlgorithm))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if unique, err := p.HasUniqueSolutionContext(ctx); unique || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestNewMultiPuzzle_Invalid(t *testing.T) {
+	t.Run("Size", func(t *testing.T) {
+		tests := []struct {
+			Name     string
+			Items    []int
+			Width    int
+			GridSize int
+			Grids    []GridPosition
+		}{
+			{Name: "NoItems", Items: nil, Width: 4, GridSize: 4, Grids: []GridPosition{{}}},
+			{Name: "NotRectangular", Items: make([]int, 17), Width: 4, GridSize: 4, Grids: []GridPosition{{}}},
+			{Name: "NoGrids", Items: make([]int, 16), Width: 4, GridSize: 4},
+			{Name: "GridOutside", Items: make([]int, 16), Width: 4, GridSize: 4, Grids: []GridPosition{{Row: 1}}},
+			{Name: "NoSquareSections", Items: make([]int, 25), Width: 5, GridSize: 5, Grids: []GridPosition{{}}},
+		}
+		for _, tc := range tests {
+			t.Run(tc.Name, func(t *testing.T) {
+				_, err := NewMultiPuzzle(tc.Items, tc.Width, tc.GridSize, tc.Grids)
+				if !errors.Is(err, ErrInvalidPuzzleSize) {
+					t.Errorf("expected error %v, got %v", ErrInvalidPuzzleSize, err)
+				}
+			})
+		}
+	})
+
+	t.Run("ValueOutsideGrids", func(t *testing.T) {
+		in := append([]int(nil), samuraiPuzzle...)
+		in[9] = 1
+		_, err := NewSamuraiPuzzle(in)
+		var got *InvalidValueError
+		if !errors.As(err, &got) {
+			t.Errorf("expected *InvalidValueError, got %v", err)
+			return
+		}
+		exp := &InvalidValueError{
+			CellPosition: CellPosition{Index: 9, Row: 0, Column: 9, Section: -1},
+			Value:        1,
+			Max:          0,
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("expected %+v, got %+v", exp, got)
+		}
+	})
+
+	t.Run("ConflictAcrossGrids", func(t *testing.T) {
+		// row 6 of the top left grid continues into the centre grid, which already has a 7 in it.
+		in := append([]int(nil), samuraiPuzzle...)
+		in[6*21+9] = 7
+		_, err := NewSamuraiPuzzle(in)
+		var got *ConflictError
+		if !errors.As(err, &got) {
+			t.Errorf("expected *ConflictError, got %v", err)
+			return
+		}
+		if got.Unit != "row" || got.Cell.Index != 6*21+9 || got.Other.Index != 6*21+8 {
+			t.Errorf("unexpected conflict: %+v", got)
+		}
+	})
+}
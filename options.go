@@ -0,0 +1,359 @@
+package sudoku
+
+import "fmt"
+
+// CellOrder determines the order in which the solver fills empty cells.
+type CellOrder int
+
+const (
+	// LinearCellOrder fills empty cells in index order.
+	LinearCellOrder CellOrder = iota
+	// MinimumRemainingValuesCellOrder fills the empty cell with the fewest candidates first.
+	// Ties are broken by choosing the cell with the lowest index.
+	MinimumRemainingValuesCellOrder
+)
+
+// String returns the name of the cell order.
+func (o CellOrder) String() string {
+	switch o {
+	case LinearCellOrder:
+		return "linear"
+	case MinimumRemainingValuesCellOrder:
+		return "mrv"
+	default:
+		return "unknown"
+	}
+}
+
+// Algorithm is the search algorithm used to solve a puzzle.
+type Algorithm int
+
+const (
+	// BacktrackingAlgorithm fills empty cells one at a time, backtracking when a cell has no values left to try.
+	BacktrackingAlgorithm Algorithm = iota
+	// DancingLinksAlgorithm solves the puzzle as an exact cover problem using Knuth's Algorithm X with dancing links.
+	// It is much faster than backtracking on hard puzzles with few givens.
+	DancingLinksAlgorithm
+)
+
+// String returns the name of the algorithm.
+func (a Algorithm) String() string {
+	switch a {
+	case BacktrackingAlgorithm:
+		return "backtracking"
+	case DancingLinksAlgorithm:
+		return "dlx"
+	default:
+		return "unknown"
+	}
+}
+
+// options contains the configuration used when creating a Puzzle.
+type options struct {
+	// sectionWidth and sectionHeight are 0 if sections should be square.
+	sectionWidth  int
+	sectionHeight int
+	// regionMap is nil unless the sections are irregular.
+	regionMap []int
+	// latinSquare is true if the puzzle has no sections, only rows and columns.
+	latinSquare bool
+	algorithm   Algorithm
+	cellOrder   CellOrder
+	propagation bool
+	diagonals   bool
+	windows     bool
+	antiKnight  bool
+	antiKing    bool
+	// nonConsecutive is true if adjacent cells cannot hold consecutive values.
+	nonConsecutive bool
+	// sandwiches and skyscrapers are converted to constraints once the puzzle size is known.
+	sandwiches  []OutsideClue
+	skyscrapers []OutsideClue
+	// odd and even hold the indexes of cells restricted to odd or even values.
+	odd         []int
+	even        []int
+	constraints []Constraint
+}
+
+// defaultOptions returns the options used when no Option overrides them.
+func defaultOptions() *options {
+	return &options{
+		algorithm:   BacktrackingAlgorithm,
+		cellOrder:   LinearCellOrder,
+		propagation: true,
+	}
+}
+
+// sections returns the sections of a puzzle with the given items and size.
+func (o *options) sections(items []int, puzzleSize int) ([]*Region, error) {
+	if o.latinSquare {
+		return nil, nil
+	}
+	if o.regionMap != nil {
+		return SectionsFromRegionMap(puzzleSize, o.regionMap)
+	}
+	sectionWidth, sectionHeight, err := o.sectionSize(items, puzzleSize)
+	if err != nil {
+		return nil, err
+	}
+	return Sections(puzzleSize, sectionWidth, sectionHeight), nil
+}
+
+// sectionSize returns the width and height of the rectangular sections of a puzzle with the given items and size.
+func (o *options) sectionSize(items []int, puzzleSize int) (int, int, error) {
+	sectionWidth, sectionHeight := o.sectionWidth, o.sectionHeight
+	if sectionWidth == 0 && sectionHeight == 0 {
+		sectionSize, err := CalculateSectionSize(items, puzzleSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		sectionWidth, sectionHeight = sectionSize, sectionSize
+	}
+	if sectionWidth <= 0 || sectionHeight <= 0 || sectionWidth*sectionHeight != puzzleSize {
+		return 0, 0, fmt.Errorf("%w: a puzzle size of %d cannot be split into sections of %dx%d",
+			ErrInvalidPuzzleSize, puzzleSize, sectionWidth, sectionHeight)
+	}
+	return sectionWidth, sectionHeight, nil
+}
+
+// extraRegions returns the diagonals, windows and chess move regions of a puzzle with the given items and size,
+// if they have been enabled.
+func (o *options) extraRegions(items []int, puzzleSize int) ([]*Region, error) {
+	var regions []*Region
+	if o.diagonals {
+		regions = append(regions, Diagonals(puzzleSize)...)
+	}
+	if o.windows {
+		sectionWidth, sectionHeight, err := o.sectionSize(items, puzzleSize)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, Windows(puzzleSize, sectionWidth, sectionHeight)...)
+	}
+	if o.antiKnight {
+		regions = append(regions, KnightMoves(puzzleSize)...)
+	}
+	if o.antiKing {
+		regions = append(regions, KingMoves(puzzleSize)...)
+	}
+	return regions, nil
+}
+
+// Option configures a Puzzle when it is created.
+type Option func(o *options)
+
+// WithSectionSize sets the width and height of each section in the puzzle, allowing rectangular sections.
+// By default sections are square.
+func WithSectionSize(width int, height int) Option {
+	return func(o *options) {
+		o.sectionWidth = width
+		o.sectionHeight = height
+	}
+}
+
+// WithRegionMap replaces the sections of the puzzle with irregular regions, as used by jigsaw puzzles.
+// The region map holds a region ID for every cell in the puzzle, see SectionsFromRegionMap.
+// Jigsaw puzzles don't need square sections, so any puzzle size can be used.
+// WithSectionSize is ignored when a region map is given.
+func WithRegionMap(regionMap []int) Option {
+	return func(o *options) {
+		o.regionMap = regionMap
+	}
+}
+
+// WithLatinSquare removes the sections of the puzzle, leaving only its rows and columns,
+// so the puzzle is solved as a Latin square. Latin squares don't need sections, so any puzzle size can be used.
+// WithSectionSize and WithRegionMap are ignored, and the Section of every cell position is -1.
+func WithLatinSquare() Option {
+	return func(o *options) {
+		o.latinSquare = true
+	}
+}
+
+// WithAlgorithm sets the search algorithm used to solve the puzzle.
+// The default is BacktrackingAlgorithm.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(o *options) {
+		o.algorithm = algorithm
+	}
+}
+
+// WithCellOrder sets the order in which the backtracking algorithm fills empty cells.
+// The default is LinearCellOrder.
+func WithCellOrder(order CellOrder) Option {
+	return func(o *options) {
+		o.cellOrder = order
+	}
+}
+
+// WithPropagation sets whether the backtracking algorithm fills in naked and hidden singles before the search
+// starts and after every guess.
+// Propagation is enabled by default.
+func WithPropagation(enabled bool) Option {
+	return func(o *options) {
+		o.propagation = enabled
+	}
+}
+
+// WithDiagonals requires both main diagonals of the puzzle to contain every value exactly once,
+// as in Sudoku-X puzzles.
+func WithDiagonals() Option {
+	return func(o *options) {
+		o.diagonals = true
+	}
+}
+
+// WithWindows adds the extra window regions of Hyper or Windoku puzzles, see Windows.
+// Windows are the same size as the sections given by WithSectionSize, and are square by default.
+func WithWindows() Option {
+	return func(o *options) {
+		o.windows = true
+	}
+}
+
+// WithAntiKnight prevents cells a chess knight's move apart from holding the same value, see KnightMoves.
+// It can be combined with WithAntiKing and any other option.
+func WithAntiKnight() Option {
+	return func(o *options) {
+		o.antiKnight = true
+	}
+}
+
+// WithAntiKing prevents cells a chess king's move apart from holding the same value, see KingMoves.
+// It can be combined with WithAntiKnight and any other option.
+func WithAntiKing() Option {
+	return func(o *options) {
+		o.antiKing = true
+	}
+}
+
+// WithNonConsecutive prevents horizontally or vertically adjacent cells from holding consecutive values,
+// see NonConsecutiveEdges.
+func WithNonConsecutive() Option {
+	return func(o *options) {
+		o.nonConsecutive = true
+	}
+}
+
+// WithEdges adds constraints between pairs of cells, such as greater-than signs and Kropki dots.
+// An edge between a cell and itself is invalid, and ErrInvalidConstraint is returned.
+func WithEdges(edges ...*Edge) Option {
+	return func(o *options) {
+		for _, e := range edges {
+			o.constraints = append(o.constraints, e)
+		}
+	}
+}
+
+// WithThermometers adds thermometers to the puzzle, along which values must strictly increase from the bulb.
+// A thermometer longer than the puzzle size is invalid, and ErrInvalidConstraint is returned.
+func WithThermometers(thermometers ...*Thermometer) Option {
+	return func(o *options) {
+		for _, t := range thermometers {
+			o.constraints = append(o.constraints, t)
+		}
+	}
+}
+
+// WithArrows adds arrows to the puzzle, where the values along each arrow must add up to the value in its circle.
+// An arrow with no cells, or too many cells to add up to the puzzle size, is invalid and ErrInvalidConstraint is returned.
+func WithArrows(arrows ...*Arrow) Option {
+	return func(o *options) {
+		for _, a := range arrows {
+			o.constraints = append(o.constraints, a)
+		}
+	}
+}
+
+// WithSandwiches adds sandwich clues to the puzzle, each giving the sum of the values between the 1
+// and the largest value in a row or column. See Sandwich.
+// Clues outside of the puzzle are invalid, and ErrInvalidConstraint is returned.
+func WithSandwiches(clues ...OutsideClue) Option {
+	return func(o *options) {
+		o.sandwiches = append(o.sandwiches, clues...)
+	}
+}
+
+// WithSkyscrapers adds skyscraper clues to the puzzle, each giving the number of values visible from its side
+// of a row or column. See Skyscraper.
+// Clues outside of the puzzle are invalid, and ErrInvalidConstraint is returned.
+func WithSkyscrapers(clues ...OutsideClue) Option {
+	return func(o *options) {
+		o.skyscrapers = append(o.skyscrapers, clues...)
+	}
+}
+
+// WithLittleKillers adds little killer clues to the puzzle, each giving the sum of the values along a diagonal.
+// See DiagonalFrom for finding the cells of a diagonal.
+func WithLittleKillers(clues ...*LittleKiller) Option {
+	return func(o *options) {
+		for _, k := range clues {
+			o.constraints = append(o.constraints, k)
+		}
+	}
+}
+
+// WithAllowedValues restricts cells to the given sets of values.
+// A set with no values, or values outside of the puzzle, is invalid and ErrInvalidConstraint is returned.
+func WithAllowedValues(sets ...*AllowedValues) Option {
+	return func(o *options) {
+		for _, a := range sets {
+			o.constraints = append(o.constraints, a)
+		}
+	}
+}
+
+// WithOdd restricts the cells at the given indexes to odd values, see OddCells.
+func WithOdd(indexes ...int) Option {
+	return func(o *options) {
+		o.odd = append(o.odd, indexes...)
+	}
+}
+
+// WithEven restricts the cells at the given indexes to even values, see EvenCells.
+func WithEven(indexes ...int) Option {
+	return func(o *options) {
+		o.even = append(o.even, indexes...)
+	}
+}
+
+// parityConstraints returns the constraints restricting the odd and even cells of a puzzle of the given size.
+func (o *options) parityConstraints(puzzleSize int) []Constraint {
+	var constraints []Constraint
+	if len(o.odd) > 0 {
+		constraints = append(constraints, OddCells(puzzleSize, o.odd...))
+	}
+	if len(o.even) > 0 {
+		constraints = append(constraints, EvenCells(puzzleSize, o.even...))
+	}
+	return constraints
+}
+
+// WithRegions adds regions the puzzle must satisfy on top of its rows, columns and sections,
+// with no value repeated within each region.
+// A region covering more cells than the puzzle size is invalid, and ErrInvalidConstraint is returned.
+func WithRegions(regions ...*Region) Option {
+	return func(o *options) {
+		for _, r := range regions {
+			o.constraints = append(o.constraints, r)
+		}
+	}
+}
+
+// WithCages adds killer sudoku cages to the puzzle.
+// Each cage must be able to add up to its sum using different values, otherwise ErrInvalidConstraint is returned.
+func WithCages(cages ...*Cage) Option {
+	return func(o *options) {
+		for _, c := range cages {
+			o.constraints = append(o.constraints, c)
+		}
+	}
+}
+
+// WithConstraints adds constraints the puzzle must satisfy on top of its rows, columns and sections.
+// It can be used more than once to add several sets of constraints.
+func WithConstraints(constraints ...Constraint) Option {
+	return func(o *options) {
+		o.constraints = append(o.constraints, constraints...)
+	}
+}
@@ -0,0 +1,335 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// Side is a side of the puzzle that an outside clue is written on.
+type Side int
+
+const (
+	// TopSide clues are written above a column.
+	TopSide Side = iota
+	// BottomSide clues are written below a column.
+	BottomSide
+	// LeftSide clues are written to the left of a row.
+	LeftSide
+	// RightSide clues are written to the right of a row.
+	RightSide
+)
+
+// String returns the name of the side.
+func (s Side) String() string {
+	switch s {
+	case TopSide:
+		return "top"
+	case BottomSide:
+		return "bottom"
+	case LeftSide:
+		return "left"
+	case RightSide:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+// OutsideClue is a clue written outside the puzzle next to a row or column, such as a sandwich sum
+// or a skyscraper count.
+type OutsideClue struct {
+	Side Side
+	// Line is the column of a TopSide or BottomSide clue, or the row of a LeftSide or RightSide clue,
+	// starting at 0.
+	Line  int
+	Value int
+}
+
+// LineFrom returns the indexes of the cells in a row or column of a puzzle of the given size,
+// in the order they are seen from the given side.
+// An error wrapping ErrInvalidConstraint is returned if the side or line is not in the puzzle.
+func LineFrom(puzzleSize int, side Side, line int) ([]int, error) {
+	if line < 0 || line >= puzzleSize {
+		return nil, fmt.Errorf("%w: line %d is outside of the puzzle", ErrInvalidConstraint, line)
+	}
+	indexes := make([]int, puzzleSize)
+	for i := range indexes {
+		switch side {
+		case TopSide:
+			indexes[i] = i*puzzleSize + line
+		case BottomSide:
+			indexes[i] = (puzzleSize-1-i)*puzzleSize + line
+		case LeftSide:
+			indexes[i] = line*puzzleSize + i
+		case RightSide:
+			indexes[i] = line*puzzleSize + puzzleSize - 1 - i
+		default:
+			return nil, fmt.Errorf("%w: unknown side %d", ErrInvalidConstraint, side)
+		}
+	}
+	return indexes, nil
+}
+
+// Sandwich is a constraint requiring the values between the 1 and the largest value in a row or column
+// to add up to Sum.
+//
+// Candidates are pruned using the cells the 1 and the largest value can still go in, and the sums
+// that can still be made between them from the values left in the line. For example a sum of 0 forces
+// the two ends to be next to each other as soon as one of them is placed.
+type Sandwich struct {
+	Sum int
+	// Indexes are the indexes of every cell in the row or column.
+	Indexes []int
+}
+
+// Cells returns the indexes of the cells in the row or column.
+func (s *Sandwich) Cells() []int {
+	return s.Indexes
+}
+
+// Allowed returns true if the cells between the 1 and the largest value can still add up to the sum.
+// Any end of the sandwich that has not been placed yet can go in any empty cell of the line.
+func (s *Sandwich) Allowed(values Values, index int, value int) bool {
+	size := values.Size()
+	valueAt := func(i int) int {
+		if s.Indexes[i] == index {
+			return value
+		}
+		return values.Value(s.Indexes[i])
+	}
+	first, last := -1, -1
+	for i := range s.Indexes {
+		if v := valueAt(i); v == 1 || v == size {
+			if first < 0 {
+				first = i
+			} else {
+				last = i
+			}
+		}
+	}
+
+	switch {
+	case last >= 0:
+		return s.canFill(size, valueAt, first, last)
+	case first >= 0:
+		// the other end can go in any empty cell.
+		for i := range s.Indexes {
+			if i != first && valueAt(i) == 0 && s.canFill(size, valueAt, first, i) {
+				return true
+			}
+		}
+		return false
+	default:
+		// both ends can go in any pair of empty cells.
+		for i := range s.Indexes {
+			if valueAt(i) != 0 {
+				continue
+			}
+			for j := i + 1; j < len(s.Indexes); j++ {
+				if valueAt(j) == 0 && s.canFill(size, valueAt, i, j) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// canFill returns true if the cells between the ends of the sandwich at positions a and b of the line
+// can add up to the sum, where valueAt returns the value at each position of the line.
+func (s *Sandwich) canFill(size int, valueAt func(i int) int, a int, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	// the filling can only hold values other than the ends that are not already in the line.
+	available := fullBitset(size).without(1).without(size)
+	sum, empty := 0, 0
+	for i := a + 1; i < b; i++ {
+		v := valueAt(i)
+		if v == 0 {
+			empty++
+			continue
+		}
+		sum += v
+		available = available.without(v)
+	}
+	return canMakeSum(available, empty, s.Sum-sum)
+}
+
+// String returns a description of the sandwich.
+func (s *Sandwich) String() string {
+	return fmt.Sprintf("sandwich of %d %v", s.Sum, s.Indexes)
+}
+
+// validate returns an error if the sandwich does not cover a whole row or column of a puzzle of the given size,
+// or its sum can never be made.
+func (s *Sandwich) validate(puzzleSize int) error {
+	if len(s.Indexes) != puzzleSize {
+		return fmt.Errorf("%w: %v covers %d cells, expected %d", ErrInvalidConstraint, s, len(s.Indexes), puzzleSize)
+	}
+	filling := fullBitset(puzzleSize).without(1).without(puzzleSize)
+	for count := 0; count <= filling.count(); count++ {
+		if canMakeSum(filling, count, s.Sum) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v cannot add up to %d", ErrInvalidConstraint, s, s.Sum)
+}
+
+// Skyscraper is a constraint requiring Count values in a row or column to be visible from its first cell,
+// where each value is the height of a skyscraper that hides every smaller value behind it.
+//
+// Candidates are pruned using the values seen so far, so the first cell of a row with a count of 1
+// must hold the largest value.
+type Skyscraper struct {
+	Count int
+	// Indexes are the indexes of every cell in the row or column, in the order they are seen.
+	Indexes []int
+}
+
+// Cells returns the indexes of the cells in the row or column.
+func (s *Skyscraper) Cells() []int {
+	return s.Indexes
+}
+
+// Allowed returns true if the number of visible values can still be Count.
+// Values are counted from the first cell until the first empty cell, after which each remaining cell
+// can add at most one more visible value as long as there are larger values left to place.
+func (s *Skyscraper) Allowed(values Values, index int, value int) bool {
+	size := values.Size()
+	if value == size {
+		// at most one value can be seen for each cell up to and including the tallest.
+		for i, cellIndex := range s.Indexes {
+			if cellIndex == index && i+1 < s.Count {
+				return false
+			}
+		}
+	}
+	visible, tallest := 0, 0
+	for i, cellIndex := range s.Indexes {
+		v := values.Value(cellIndex)
+		if cellIndex == index {
+			v = value
+		}
+		if v == 0 {
+			remaining := len(s.Indexes) - i
+			if taller := size - tallest; taller < remaining {
+				remaining = taller
+			}
+			// the largest value hasn't been placed yet, so at least one more value will be seen.
+			return visible+1 <= s.Count && visible+remaining >= s.Count
+		}
+		if v > tallest {
+			visible++
+			tallest = v
+		}
+		if tallest == size {
+			// nothing after the tallest value can be seen.
+			return visible == s.Count
+		}
+	}
+	return visible == s.Count
+}
+
+// String returns a description of the skyscraper clue.
+func (s *Skyscraper) String() string {
+	return fmt.Sprintf("skyscraper of %d %v", s.Count, s.Indexes)
+}
+
+// validate returns an error if the clue does not cover a whole row or column of a puzzle of the given size,
+// or the count is not between 1 and the puzzle size.
+func (s *Skyscraper) validate(puzzleSize int) error {
+	if len(s.Indexes) != puzzleSize {
+		return fmt.Errorf("%w: %v covers %d cells, expected %d", ErrInvalidConstraint, s, len(s.Indexes), puzzleSize)
+	}
+	if s.Count < 1 || s.Count > puzzleSize {
+		return fmt.Errorf("%w: %v must see between 1 and %d values", ErrInvalidConstraint, s, puzzleSize)
+	}
+	return nil
+}
+
+// LittleKiller is a constraint requiring the values along a diagonal to add up to Sum.
+// Unlike a cage, values can be repeated along the diagonal unless another constraint prevents it.
+type LittleKiller struct {
+	Sum int
+	// Indexes are the indexes of the cells along the diagonal.
+	Indexes []int
+}
+
+// Cells returns the indexes of the cells along the diagonal.
+func (k *LittleKiller) Cells() []int {
+	return k.Indexes
+}
+
+// Allowed returns true if the empty cells along the diagonal can still be filled to reach the sum,
+// with each empty cell adding at least 1 and at most the puzzle size.
+func (k *LittleKiller) Allowed(values Values, index int, value int) bool {
+	sum, empty := 0, 0
+	for _, cellIndex := range k.Indexes {
+		v := values.Value(cellIndex)
+		if cellIndex == index {
+			v = value
+		}
+		if v == 0 {
+			empty++
+			continue
+		}
+		sum += v
+	}
+	return sum+empty <= k.Sum && k.Sum <= sum+empty*values.Size()
+}
+
+// String returns a description of the little killer clue.
+func (k *LittleKiller) String() string {
+	return fmt.Sprintf("little killer of %d %v", k.Sum, k.Indexes)
+}
+
+// validate returns an error if the diagonal covers a cell more than once,
+// or its sum can never be made in a puzzle of the given size.
+func (k *LittleKiller) validate(puzzleSize int) error {
+	if k.Sum < len(k.Indexes) || k.Sum > len(k.Indexes)*puzzleSize {
+		return fmt.Errorf("%w: %v cannot add up to %d using values between 1 and %d",
+			ErrInvalidConstraint, k, k.Sum, puzzleSize)
+	}
+	return validateLine(k, k.Indexes)
+}
+
+// DiagonalFrom returns the indexes of the cells along a diagonal of a puzzle of the given size, starting at the cell
+// at the given index and moving one row down or up and one column right or left with each step,
+// until the edge of the puzzle is reached. It is used to find the cells of a little killer clue.
+func DiagonalFrom(puzzleSize int, index int, down bool, right bool) []int {
+	rowStep, columnStep := 1, 1
+	if !down {
+		rowStep = -1
+	}
+	if !right {
+		columnStep = -1
+	}
+	var indexes []int
+	row, column := getRowFromIndex(index, puzzleSize), getColumnFromIndex(index, puzzleSize)
+	for row >= 0 && row < puzzleSize && column >= 0 && column < puzzleSize {
+		indexes = append(indexes, row*puzzleSize+column)
+		row += rowStep
+		column += columnStep
+	}
+	return indexes
+}
+
+// outsideConstraints returns the sandwich and skyscraper constraints for the given clues in a puzzle of the given size.
+func outsideConstraints(puzzleSize int, sandwiches []OutsideClue, skyscrapers []OutsideClue) ([]Constraint, error) {
+	var constraints []Constraint
+	for _, clue := range sandwiches {
+		indexes, err := LineFrom(puzzleSize, clue.Side, clue.Line)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, &Sandwich{Sum: clue.Value, Indexes: indexes})
+	}
+	for _, clue := range skyscrapers {
+		indexes, err := LineFrom(puzzleSize, clue.Side, clue.Line)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, &Skyscraper{Count: clue.Value, Indexes: indexes})
+	}
+	return constraints, nil
+}
@@ -0,0 +1,319 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// outsideClues returns a clue on the given side of every row or column, with the given values.
+func outsideClues(side Side, values ...int) []OutsideClue {
+	clues := make([]OutsideClue, len(values))
+	for i, value := range values {
+		clues[i] = OutsideClue{Side: side, Line: i, Value: value}
+	}
+	return clues
+}
+
+func TestLineFrom(t *testing.T) {
+	tests := []struct {
+		Side Side
+		Line int
+		Exp  []int
+	}{
+		{Side: TopSide, Line: 1, Exp: []int{1, 5, 9, 13}},
+		{Side: BottomSide, Line: 1, Exp: []int{13, 9, 5, 1}},
+		{Side: LeftSide, Line: 2, Exp: []int{8, 9, 10, 11}},
+		{Side: RightSide, Line: 2, Exp: []int{11, 10, 9, 8}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Side.String(), func(t *testing.T) {
+			got, err := LineFrom(4, tc.Side, tc.Line)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(tc.Exp, got) {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := LineFrom(4, TopSide, 4); !errors.Is(err, ErrInvalidConstraint) {
+			t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+		}
+		if _, err := LineFrom(4, Side(-1), 0); !errors.Is(err, ErrInvalidConstraint) {
+			t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+		}
+	})
+}
+
+func TestDiagonalFrom(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Index int
+		Down  bool
+		Right bool
+		Exp   []int
+	}{
+		{Name: "DownRight", Index: 1, Down: true, Right: true, Exp: []int{1, 6, 11}},
+		{Name: "DownLeft", Index: 3, Down: true, Right: false, Exp: []int{3, 6, 9, 12}},
+		{Name: "UpRight", Index: 8, Down: false, Right: true, Exp: []int{8, 5, 2}},
+		{Name: "UpLeft", Index: 15, Down: false, Right: false, Exp: []int{15, 10, 5, 0}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := DiagonalFrom(4, tc.Index, tc.Down, tc.Right); !reflect.DeepEqual(tc.Exp, got) {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestSandwich_Allowed(t *testing.T) {
+	sandwich := &Sandwich{Sum: 7, Indexes: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+	tests := []struct {
+		Name   string
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		{Name: "OneEnd", Values: []int{1}, Index: 4, Value: 8, Exp: true},
+		{Name: "Between", Values: []int{1, 0, 0, 9}, Index: 1, Value: 3, Exp: true},
+		// the other cell would need to hold 1, which is an end of the sandwich.
+		{Name: "NoFilling", Values: []int{1, 0, 0, 9}, Index: 1, Value: 6, Exp: false},
+		{Name: "Sum", Values: []int{1, 3, 0, 9}, Index: 2, Value: 4, Exp: true},
+		{Name: "WrongSum", Values: []int{1, 3, 0, 9}, Index: 2, Value: 5, Exp: false},
+		// 2 + 5 is the only way to make 7 from two values without repeating the 3.
+		{Name: "Repeated", Values: []int{9, 3, 0, 0, 1}, Index: 2, Value: 2, Exp: false},
+		{Name: "PlaceEnd", Values: []int{9, 3, 4}, Index: 3, Value: 1, Exp: true},
+		{Name: "PlaceEndTooFar", Values: []int{9, 3, 4}, Index: 4, Value: 1, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			values := make(valueSlice, 81)
+			copy(values, tc.Values)
+			if got := sandwich.Allowed(values, tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestSandwich_Allowed_PartialLine(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Sum    int
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		// with a sum of 0 the largest value must be next to the 1.
+		{Name: "OtherEndNextTo", Sum: 0, Values: []int{0, 0, 1}, Index: 3, Value: 9, Exp: true},
+		{Name: "OtherEndTooFar", Sum: 0, Values: []int{0, 0, 1}, Index: 5, Value: 9, Exp: false},
+		// the largest value can still go before the 1.
+		{Name: "FillingOneSide", Sum: 0, Values: []int{0, 0, 1}, Index: 3, Value: 5, Exp: true},
+		{Name: "FillingBothSides", Sum: 0, Values: []int{0, 4, 1}, Index: 3, Value: 5, Exp: false},
+		// a sum of 35 needs every value from 2 to 8, so the ends must be the first and last cells.
+		{Name: "NoEnds", Sum: 35, Values: nil, Index: 4, Value: 5, Exp: true},
+		{Name: "NoEndsFirstCell", Sum: 35, Values: nil, Index: 0, Value: 5, Exp: false},
+		{Name: "NoEndsEndTooSoon", Sum: 35, Values: nil, Index: 1, Value: 1, Exp: false},
+		{Name: "NoEndsEnd", Sum: 35, Values: nil, Index: 8, Value: 9, Exp: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			sandwich := &Sandwich{Sum: tc.Sum, Indexes: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+			values := make(valueSlice, 81)
+			copy(values, tc.Values)
+			if got := sandwich.Allowed(values, tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestSkyscraper_Allowed(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Count  int
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		{Name: "OneVisible", Count: 1, Values: nil, Index: 0, Value: 9, Exp: true},
+		{Name: "OneVisibleNotTallest", Count: 1, Values: nil, Index: 0, Value: 8, Exp: false},
+		// only 9 can be seen after an 8.
+		{Name: "NotEnoughTaller", Count: 3, Values: nil, Index: 0, Value: 8, Exp: false},
+		{Name: "TallestTooSoon", Count: 4, Values: nil, Index: 2, Value: 9, Exp: false},
+		{Name: "TallestLateEnough", Count: 4, Values: nil, Index: 3, Value: 9, Exp: true},
+		{Name: "TooManyVisible", Count: 2, Values: []int{1, 2}, Index: 2, Value: 3, Exp: false},
+		{Name: "Hidden", Count: 3, Values: []int{1, 5}, Index: 2, Value: 3, Exp: true},
+		// the 9 must still be seen after the 1 and 5.
+		{Name: "TallestStillToCome", Count: 2, Values: []int{1, 5}, Index: 2, Value: 3, Exp: false},
+		{Name: "Complete", Count: 3, Values: []int{1, 5, 3, 9}, Index: 2, Value: 3, Exp: true},
+		{Name: "CompleteWrongCount", Count: 2, Values: []int{1, 5, 3, 9}, Index: 2, Value: 3, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			skyscraper := &Skyscraper{Count: tc.Count, Indexes: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}}
+			values := make(valueSlice, 81)
+			copy(values, tc.Values)
+			if got := skyscraper.Allowed(values, tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestLittleKiller_Allowed(t *testing.T) {
+	killer := &LittleKiller{Sum: 10, Indexes: []int{0, 10, 20}}
+	tests := []struct {
+		Name   string
+		Values []int
+		Index  int
+		Value  int
+		Exp    bool
+	}{
+		{Name: "Empty", Values: nil, Index: 0, Value: 8, Exp: true},
+		// the other two cells add at least 2.
+		{Name: "TooLarge", Values: nil, Index: 0, Value: 9, Exp: false},
+		{Name: "Sum", Values: []int{4, 10: 4}, Index: 20, Value: 2, Exp: true},
+		{Name: "WrongSum", Values: []int{4, 10: 4}, Index: 20, Value: 3, Exp: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			values := make(valueSlice, 81)
+			copy(values, tc.Values)
+			if got := killer.Allowed(values, tc.Index, tc.Value); tc.Exp != got {
+				t.Errorf("expected %v, got %v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Solve_OutsideClues(t *testing.T) {
+	// the givens of TestPuzzle_Solve_Diagonals, which only has a unique solution with the outside clues.
+	in := []int{
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 5, 0, 3, 7, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 2, 3, 0,
+		0, 0, 0, 6, 0, 0, 0, 1, 0,
+		2, 0, 0, 0, 0, 0, 4, 9, 0,
+		0, 0, 0, 7, 0, 0, 0, 0, 2,
+		0, 1, 0, 0, 0, 8, 7, 0, 5,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 9, 0, 0, 0, 0, 1, 0, 0,
+	}
+	tests := []struct {
+		Name    string
+		Options []Option
+	}{
+		{
+			Name: "Sandwich",
+			Options: []Option{
+				WithSandwiches(outsideClues(LeftSide, 35, 10, 0, 8, 4, 6, 7, 17, 20)...),
+				WithSandwiches(outsideClues(TopSide, 16, 4, 12, 0, 0, 0, 30, 0, 32)...),
+			},
+		},
+		{
+			Name: "Skyscraper",
+			Options: []Option{
+				WithSkyscrapers(outsideClues(LeftSide, 1, 4, 3, 4, 4, 3, 2, 4, 2)...),
+				WithSkyscrapers(outsideClues(RightSide, 4, 2, 4, 3, 2, 4, 4, 1, 5)...),
+				WithSkyscrapers(outsideClues(TopSide, 1, 3, 3, 2, 4, 3, 2, 2, 3)...),
+				WithSkyscrapers(outsideClues(BottomSide, 2, 1, 3, 3, 2, 3, 5, 3, 2)...),
+			},
+		},
+		{
+			Name: "LittleKiller",
+			Options: []Option{WithLittleKillers(
+				&LittleKiller{Sum: 34, Indexes: DiagonalFrom(9, 1, true, true)},
+				&LittleKiller{Sum: 41, Indexes: DiagonalFrom(9, 7, true, false)},
+				&LittleKiller{Sum: 15, Indexes: DiagonalFrom(9, 6, true, true)},
+				&LittleKiller{Sum: 12, Indexes: DiagonalFrom(9, 54, true, true)},
+				&LittleKiller{Sum: 15, Indexes: DiagonalFrom(9, 18, false, true)},
+				&LittleKiller{Sum: 30, Indexes: DiagonalFrom(9, 27, true, true)},
+				&LittleKiller{Sum: 26, Indexes: DiagonalFrom(9, 45, false, true)},
+				&LittleKiller{Sum: 35, Indexes: DiagonalFrom(9, 3, true, true)},
+				&LittleKiller{Sum: 48, Indexes: DiagonalFrom(9, 17, true, false)},
+			)},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			t.Run(tc.Name+"/"+config.Name, func(t *testing.T) {
+				p, err := NewPuzzle(in, append(config.Options, tc.Options...)...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				if err := p.Solve(); err != nil {
+					t.Errorf("could not solve puzzle: %s", err)
+					return
+				}
+				got, err := p.Result()
+				if err != nil {
+					t.Errorf("could not get result: %s", err)
+					return
+				}
+				if !reflect.DeepEqual(edgeSolution, got) {
+					t.Errorf("expected %v, got %v", edgeSolution, got)
+				}
+			})
+		}
+
+		t.Run(tc.Name+"/Unique", func(t *testing.T) {
+			p, err := NewPuzzle(in, tc.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if unique, err := p.HasUniqueSolution(); !unique {
+				t.Errorf("expected puzzle to be unique, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_OutsideClues_Invalid(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Option Option
+	}{
+		// the values between 1 and 4 can add up to at most 5.
+		{Name: "SandwichTooLarge", Option: WithSandwiches(OutsideClue{Side: LeftSide, Line: 0, Value: 6})},
+		{Name: "SandwichNotLine", Option: WithConstraints(&Sandwich{Sum: 2, Indexes: []int{0, 1, 2}})},
+		{Name: "SkyscraperNoneVisible", Option: WithSkyscrapers(OutsideClue{Side: TopSide, Line: 0, Value: 0})},
+		{Name: "SkyscraperTooMany", Option: WithSkyscrapers(OutsideClue{Side: TopSide, Line: 0, Value: 5})},
+		{Name: "LineOutside", Option: WithSkyscrapers(OutsideClue{Side: TopSide, Line: 4, Value: 1})},
+		{Name: "LittleKillerTooSmall", Option: WithLittleKillers(&LittleKiller{Sum: 2, Indexes: []int{0, 5, 10}})},
+		{Name: "LittleKillerTooLarge", Option: WithLittleKillers(&LittleKiller{Sum: 13, Indexes: []int{0, 5, 10}})},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(make([]int, 16), tc.Option)
+			if !errors.Is(err, ErrInvalidConstraint) {
+				t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+			}
+		})
+	}
+
+	t.Run("MultiGrid", func(t *testing.T) {
+		_, err := NewSamuraiPuzzle(samuraiPuzzle, WithSandwiches(OutsideClue{Side: TopSide, Line: 0, Value: 0}))
+		if !errors.Is(err, ErrInvalidConstraint) {
+			t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+		}
+	})
+}
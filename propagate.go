@@ -0,0 +1,93 @@
+package sudoku
+
+// propagate fills every cell whose value can be deduced from the current grid using naked and hidden singles,
+// repeating until no more deductions can be made.
+// Each deduction is recorded on the trail so that it is undone along with the guess that led to it.
+// It returns false if the grid can no longer be solved, which means the most recent guess was wrong.
+// gridMu must be held by the caller.
+func (p *Puzzle) propagate() bool {
+	for {
+		nakedProgress, ok := p.propagateNakedSingles()
+		if !ok {
+			return false
+		}
+		hiddenProgress, ok := p.propagateHiddenSingles()
+		if !ok {
+			return false
+		}
+		if !nakedProgress && !hiddenProgress {
+			return true
+		}
+	}
+}
+
+// propagateNakedSingles fills every empty cell that has only a single candidate.
+// It returns true if any cells were filled, and false if any cell has no candidates left.
+func (p *Puzzle) propagateNakedSingles() (bool, bool) {
+	progress := false
+	for _, c := range p.grid.cells {
+		if c.value != 0 {
+			continue
+		}
+		candidates := p.grid.candidates(c)
+		switch candidates.count() {
+		case 0:
+			return progress, false
+		case 1:
+			p.deduce(c, candidates.lowest())
+			progress = true
+		}
+	}
+	return progress, true
+}
+
+// propagateHiddenSingles fills every cell that is the only place a value can go within one of its regions.
+// Only regions that must contain every value are checked.
+// It returns true if any cells were filled, and false if a region has a missing value that cannot be placed.
+func (p *Puzzle) propagateHiddenSingles() (bool, bool) {
+	progress := false
+	for _, r := range p.grid.regions {
+		if len(r.Indexes) != p.grid.puzzleSize {
+			continue
+		}
+		var placed, once, twice bitset
+		for _, cellIndex := range r.Indexes {
+			c := p.grid.cells[cellIndex]
+			if c.value != 0 {
+				placed = placed.with(c.value)
+				continue
+			}
+			candidates := p.grid.candidates(c)
+			twice |= once & candidates
+			once |= candidates
+		}
+
+		missing := fullBitset(p.grid.puzzleSize) &^ placed
+		if missing&^once != 0 {
+			// a value is missing from the region and has nowhere to go.
+			return progress, false
+		}
+
+		value := (once &^ twice & missing).lowest()
+		if value == 0 {
+			continue
+		}
+		// only place a single value per region since placing it may affect the other values.
+		for _, cellIndex := range r.Indexes {
+			c := p.grid.cells[cellIndex]
+			if c.value == 0 && p.grid.candidates(c).has(value) {
+				p.deduce(c, value)
+				progress = true
+				break
+			}
+		}
+	}
+	return progress, true
+}
+
+// deduce places a value that was deduced from the current grid and records it on the trail.
+func (p *Puzzle) deduce(c *cell, value int) {
+	p.grid.place(c, value)
+	p.trail = append(p.trail, move{index: c.index, value: value, deduced: true})
+	p.deducedCells++
+}
@@ -1,6 +1,7 @@
 package sudoku
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -11,7 +12,8 @@ import (
 var (
 	// ErrNoMoreMoves is returned when there are no available moves on the current iteration.
 	ErrNoMoreMoves = errors.New("no more moves")
-	// ErrMissingIteration is returned when a required iteration is missing.
+	// ErrMissingIteration is returned when there are no previous moves left to revert,
+	// which means the puzzle has no solution.
 	ErrMissingIteration = errors.New("missing iteration")
 	// ErrInvalidPuzzleSize is returned when the puzzle size or section size cannot be calculated.
 	ErrInvalidPuzzleSize = errors.New("invalid puzzle size")
@@ -19,41 +21,112 @@ var (
 
 // CompletionRate contains information on a puzzles completion.
 type CompletionRate struct {
-	Completed           bool
-	Failed              bool
+	// Completed is true if every cell has a value, either because every cell was given
+	// or because the search has found a solution. Cells filled by a search that is still running
+	// do not count until the search finishes.
+	Completed bool
+	Failed    bool
+	// Cancelled is true if the solve was stopped by its context before it could complete.
+	Cancelled bool
+	// TimedOut is true if the solve was cancelled because its context deadline was exceeded.
+	TimedOut            bool
 	Error               error
 	TotalCells          int
 	FixedCells          int
 	FilledCells         int
 	AttemptedIterations int
-	CellIndex           int
-	MinValueAtCell      int
-	StartedAt           time.Time
-	FailedAt            time.Time
-	CompletedAt         time.Time
+	// DeducedCells is the number of filled cells that were deduced using constraint propagation.
+	DeducedCells int
+	// GuessedCells is the number of filled cells that were guessed by the search.
+	GuessedCells   int
+	CellIndex      int
+	MinValueAtCell int
+	StartedAt      time.Time
+	FailedAt       time.Time
+	CompletedAt    time.Time
+	CancelledAt    time.Time
+}
+
+// NewPuzzleWithSectionSize returns a new puzzle with rectangular sections of the given width and height,
+// such as a 6x6 puzzle made up of sections 3 cells wide and 2 cells tall.
+// The section width multiplied by the section height must equal the puzzle size.
+func NewPuzzleWithSectionSize(items []int, sectionWidth int, sectionHeight int, opts ...Option) (*Puzzle, error) {
+	return NewPuzzle(items, append(opts, WithSectionSize(sectionWidth, sectionHeight))...)
 }
 
 // NewPuzzle returns a new puzzle.
-func NewPuzzle(items []int) (*Puzzle, error) {
+// Sections are square unless WithSectionSize or WithRegionMap is used, and WithLatinSquare removes them.
+// An error is returned if the items do not make up a valid puzzle: ErrInvalidPuzzleSize if the puzzle
+// is not a valid size or is larger than 64x64, ErrInvalidRegionMap if the region map is not valid, *InvalidValueError if a value
+// is out of range, *ConflictError if a value is repeated within a row, column, section or other region,
+// ErrInvalidConstraint if a constraint covers cells outside of the puzzle and *ConstraintError if a value
+// breaks any other constraint.
+func NewPuzzle(items []int, opts ...Option) (*Puzzle, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	puzzleSize, err := CalculatePuzzleSize(items)
 	if err != nil {
 		return nil, err
 	}
-	sectionSize, err := CalculateSectionSize(items, puzzleSize)
+	if puzzleSize > maxPuzzleSize {
+		return nil, fmt.Errorf("%w: puzzles larger than %dx%d are not supported", ErrInvalidPuzzleSize, maxPuzzleSize, maxPuzzleSize)
+	}
+	sections, err := o.sections(items, puzzleSize)
+	if err != nil {
+		return nil, err
+	}
+	extraRegions, err := o.extraRegions(items, puzzleSize)
+	if err != nil {
+		return nil, err
+	}
+	var constraints []Constraint
+	for _, r := range extraRegions {
+		constraints = append(constraints, r)
+	}
+	if o.nonConsecutive {
+		for _, e := range NonConsecutiveEdges(puzzleSize) {
+			constraints = append(constraints, e)
+		}
+	}
+	outside, err := outsideConstraints(puzzleSize, o.sandwiches, o.skyscrapers)
 	if err != nil {
 		return nil, err
 	}
+	constraints = append(constraints, outside...)
+	constraints = append(constraints, o.parityConstraints(puzzleSize)...)
+	constraints = append(constraints, o.constraints...)
+	return newPuzzle(items, squareLayout(puzzleSize, sections, constraints), o)
+}
+
+// newPuzzle returns a new puzzle with the given items and layout, checking the items and constraints are valid.
+func newPuzzle(items []int, l *layout, o *options) (*Puzzle, error) {
+	if err := validateValues(items, l); err != nil {
+		return nil, err
+	}
+	if err := validateConstraints(l.constraints, l.puzzleSize, l.cellCount()); err != nil {
+		return nil, err
+	}
+	g := newGrid(items, l)
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
 
-	firstIteration := newIteration(items, puzzleSize, sectionSize)
 	return &Puzzle{
-		puzzleSize: puzzleSize,
-		iterations: []*iteration{
-			firstIteration,
-		},
-		lastIteration:    nil,
-		currentIteration: firstIteration,
-
-		iterationMu:           &sync.Mutex{},
+		givens:      append([]int(nil), items...),
+		layout:      l,
+		options:     o,
+		puzzleSize:  l.puzzleSize,
+		grid:        g,
+		trail:       make([]move, 0, len(items)),
+		minValue:    1,
+		algorithm:   o.algorithm,
+		cellOrder:   o.cellOrder,
+		propagation: o.propagation,
+
+		gridMu:                &sync.Mutex{},
 		errMu:                 &sync.Mutex{},
 		attemptedIterationsMu: &sync.Mutex{},
 		timerMu:               &sync.Mutex{},
@@ -62,11 +135,35 @@ func NewPuzzle(items []int) (*Puzzle, error) {
 
 // Puzzle is a sudoku puzzle.
 type Puzzle struct {
-	iterationMu      *sync.Mutex
-	iterations       []*iteration
-	lastIteration    *iteration
-	currentIteration *iteration
-	puzzleSize       int
+	// givens, layout and options are what the puzzle was created with.
+	givens  []int
+	layout  *layout
+	options *options
+
+	gridMu *sync.Mutex
+	grid   *grid
+	// trail contains every move made by the search, in the order they were made.
+	trail []move
+	// cell is the cell the search is currently working on.
+	// It is nil when the next cell should be chosen using the cell order.
+	cell *cell
+	// index is the cell index the search is currently working on.
+	index int
+	// minValue is the lowest value the search will try in the current cell.
+	minValue    int
+	puzzleSize  int
+	algorithm   Algorithm
+	cellOrder   CellOrder
+	propagation bool
+	// deducedCells and guessedCells count the deduced and guessed moves on the trail.
+	deducedCells int
+	guessedCells int
+	// started is true once the search has started.
+	started bool
+	// solved is true if the grid currently holds a solution found by the search.
+	solved bool
+	// dlx holds the dancing links matrix when using DancingLinksAlgorithm.
+	dlx *dancingLinks
 
 	errMu                 *sync.Mutex
 	err                   error
@@ -77,110 +174,219 @@ type Puzzle struct {
 	startedAt   time.Time
 	failedAt    time.Time
 	completedAt time.Time
+	cancelledAt time.Time
 }
 
-// revert reverts back to the most recent applicable iteration.
-func (p *Puzzle) revert(firstRevert bool) error {
-	if firstRevert {
-		p.iterationMu.Lock()
-		defer p.iterationMu.Unlock()
-	}
-	if p.lastIteration == nil {
-		return ErrMissingIteration
-	}
+// revert undoes the most recent guess that still has higher values left to try,
+// along with any deductions made after it.
+// The search will continue from the reverted cell, starting at the next value up.
+func (p *Puzzle) revert() error {
+	p.gridMu.Lock()
+	defer p.gridMu.Unlock()
+	for {
+		if len(p.trail) == 0 {
+			return ErrMissingIteration
+		}
 
-	// remove the last iteration.
-	p.iterations = p.iterations[:len(p.iterations)-1]
-	iterationsLen := len(p.iterations)
-	if iterationsLen < 1 {
-		return ErrMissingIteration
-	}
-	// set the current iteration and increment the min value.
-	p.currentIteration = p.iterations[iterationsLen-1]
-	p.currentIteration.minValue++
+		// remove the last move from the trail and clear its cell.
+		last := p.trail[len(p.trail)-1]
+		p.trail = p.trail[:len(p.trail)-1]
+		p.grid.place(p.grid.cells[last.index], 0)
+		if last.deduced {
+			p.deducedCells--
+			continue
+		}
+		p.guessedCells--
+
+		p.cell = p.grid.cells[last.index]
+		p.index = last.index
+		p.minValue = last.value + 1
 
-	// set the last iteration.
-	if iterationsLen >= 2 {
-		p.lastIteration = p.iterations[iterationsLen-2]
+		// if the min value is too high, revert again.
+		if p.minValue <= p.puzzleSize {
+			return nil
+		}
 	}
+}
+
+// next guesses the given value in the given cell and moves on to the next cell.
+// It returns false if the guess leaves the grid in a state that cannot be solved.
+func (p *Puzzle) next(c *cell, value int) bool {
+	p.gridMu.Lock()
+	defer p.gridMu.Unlock()
+	p.grid.place(c, value)
+	p.trail = append(p.trail, move{index: c.index, value: value})
+	p.guessedCells++
+	p.cell = nil
+	p.index = c.index + 1
+	p.minValue = 1
+	return !p.propagation || p.propagate()
+}
 
-	// if the min value is too high, revert again.
-	if p.currentIteration.minValue > p.puzzleSize {
-		return p.revert(false)
+// selectCell returns the next cell the search should fill, or nil if there are no empty cells left.
+func (p *Puzzle) selectCell() *cell {
+	if p.cell != nil {
+		return p.cell
+	}
+	switch p.cellOrder {
+	case MinimumRemainingValuesCellOrder:
+		return p.grid.mostConstrainedCell()
+	default:
+		return p.grid.nextEmptyCell(p.index)
 	}
-	return nil
 }
 
-// next moves on to the next iteration.
-func (p *Puzzle) next() error {
-	p.iterationMu.Lock()
-	nextIteration := p.currentIteration.iterate()
-	p.lastIteration = p.currentIteration
-	p.currentIteration = nextIteration
-	p.iterations = append(p.iterations, p.currentIteration)
-	p.iterationMu.Unlock()
-	return nil
+// fail records that the solve failed with the given error.
+func (p *Puzzle) fail(err error) error {
+	p.timerMu.Lock()
+	p.failedAt = time.Now()
+	p.timerMu.Unlock()
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+	p.err = err
+	return p.err
+}
+
+// cancel records that the solve was stopped by its context.
+func (p *Puzzle) cancel(err error) error {
+	p.timerMu.Lock()
+	p.cancelledAt = time.Now()
+	p.timerMu.Unlock()
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+	p.err = fmt.Errorf("solve cancelled: %w", err)
+	return p.err
 }
 
 // Solve solves the puzzle.
 func (p *Puzzle) Solve() error {
+	return p.SolveContext(context.Background())
+}
+
+// SolveContext solves the puzzle, giving up if the given context is cancelled or its deadline is exceeded.
+// The partially solved puzzle can still be read using Result if the solve is cancelled.
+// Once a solution has been found, solving again returns nil without changing the puzzle.
+func (p *Puzzle) SolveContext(ctx context.Context) error {
+	if p.solved {
+		// the grid already holds a solution, use SolutionIterator to find the others.
+		return nil
+	}
+
+	// clear the outcome of any previous solve that was cancelled or failed, as the search resumes from where it stopped.
 	p.timerMu.Lock()
 	p.startedAt = time.Now()
+	p.failedAt = time.Time{}
+	p.completedAt = time.Time{}
+	p.cancelledAt = time.Time{}
 	p.timerMu.Unlock()
+	p.errMu.Lock()
+	p.err = nil
+	p.errMu.Unlock()
 
-	if err := p.next(); err != nil {
-		p.timerMu.Lock()
-		p.failedAt = time.Now()
-		p.timerMu.Unlock()
-		p.errMu.Lock()
-		defer p.errMu.Unlock()
-		p.err = fmt.Errorf("initial iteration failed: %w", err)
-		return p.err
-	}
-	p.iterationMu.Lock()
-	p.currentIteration.index = 0
-	p.iterationMu.Unlock()
 	p.attemptedIterationsMu.Lock()
 	p.attemptedIterations++
 	p.attemptedIterationsMu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return p.cancel(err)
+	}
+
+	found, err := p.search(ctx, false)
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return p.cancel(err)
+	case err != nil:
+		return p.fail(err)
+	case !found:
+		return p.fail(fmt.Errorf("could not find a solution: %w", ErrMissingIteration))
+	}
+
+	p.timerMu.Lock()
+	p.completedAt = time.Now()
+	p.timerMu.Unlock()
+	return nil
+}
+
+// search continues the search until the next solution is found, leaving the solution in the grid.
+// If skip is true and the grid already holds a solution, it is treated as a dead end
+// so the search moves on to the next one.
+// It returns false once there are no more solutions.
+func (p *Puzzle) search(ctx context.Context, skip bool) (bool, error) {
+	skip = skip && p.solved
+	p.solved = false
+
+	var found bool
+	var err error
+	switch p.algorithm {
+	case DancingLinksAlgorithm:
+		found, err = p.searchDancingLinks(ctx, skip)
+	default:
+		found, err = p.searchBacktracking(ctx, skip)
+	}
+	p.solved = found && err == nil
+	return found, err
+}
+
+// searchBacktracking searches for the next solution by filling empty cells one at a time,
+// reverting previous guesses when a cell has no values left to try.
+// If skip is true the solution in the grid is reverted first.
+func (p *Puzzle) searchBacktracking(ctx context.Context, skip bool) (bool, error) {
+	switch {
+	case !p.started:
+		p.started = true
+		if p.propagation {
+			p.gridMu.Lock()
+			consistent := p.propagate()
+			p.gridMu.Unlock()
+			// if the givens alone lead to a contradiction there will be nothing to revert to.
+			if !consistent && p.revert() != nil {
+				return false, nil
+			}
+		}
+	case skip:
+		// treat the previous solution as a dead end so the search moves on to the next one.
+		if p.revert() != nil {
+			return false, nil
+		}
+	}
+
 	for {
-		p.iterationMu.Lock()
-		currentIteration := p.currentIteration
-		p.iterationMu.Unlock()
-		err := currentIteration.solve()
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		p.gridMu.Lock()
+		c := p.selectCell()
+		var value int
+		var err error
+		if c != nil {
+			value, err = p.grid.findNextValue(c, p.minValue)
+		}
+		p.gridMu.Unlock()
+
+		if c == nil {
+			// all cells have a value
+			return true, nil
+		}
+
 		switch err {
 		case nil:
-			// we were able to find a matching value
-			if currentIteration.finished() {
-				// all cells have a value
-				p.timerMu.Lock()
-				p.completedAt = time.Now()
-				p.timerMu.Unlock()
-				return nil
-			}
+			// we were able to find a matching value.
 			// continue to the next index.
-			if err := p.next(); err != nil {
-				p.timerMu.Lock()
-				p.failedAt = time.Now()
-				p.timerMu.Unlock()
-				p.errMu.Lock()
-				defer p.errMu.Unlock()
-				p.err = fmt.Errorf("could not move to next iteration: %w", err)
-				return p.err
+			if p.next(c, value) {
+				continue
 			}
-			continue
+			// the guess led to a contradiction so treat it as if there were no more moves.
+			fallthrough
 
 		case ErrNoMoreMoves:
-			// the iteration ran out of moves.
-			// revert back to previous iteration with an incremented min value
-			if err := p.revert(true); err != nil {
-				p.timerMu.Lock()
-				p.failedAt = time.Now()
-				p.timerMu.Unlock()
-				p.errMu.Lock()
-				defer p.errMu.Unlock()
-				p.err = fmt.Errorf("could not revert: %w", err)
-				return p.err
+			// the cell ran out of moves.
+			// revert back to the previous guess with an incremented min value
+			if err := p.revert(); err != nil {
+				// every possibility has been tried.
+				return false, nil
 			}
 			p.attemptedIterationsMu.Lock()
 			p.attemptedIterations++
@@ -188,38 +394,48 @@ func (p *Puzzle) Solve() error {
 			continue
 
 		default:
-			p.timerMu.Lock()
-			p.failedAt = time.Now()
-			p.timerMu.Unlock()
-			p.errMu.Lock()
-			defer p.errMu.Unlock()
-			p.err = fmt.Errorf("could not solve iteration: %w", err)
-			return p.err
+			return false, fmt.Errorf("could not solve cell: %w", err)
 		}
 	}
 }
 
-// Result returns the last iteration.
+// Constraints returns every constraint the puzzle must satisfy, starting with its rows, columns and sections,
+// followed by any extra regions, the allowed values of cells and then every other constraint.
+func (p *Puzzle) Constraints() []Constraint {
+	res := make([]Constraint, 0, len(p.grid.regions)+len(p.grid.allowedValues)+len(p.grid.constraints))
+	for _, r := range p.grid.regions {
+		res = append(res, r)
+	}
+	for _, a := range p.grid.allowedValues {
+		res = append(res, a)
+	}
+	return append(res, p.grid.constraints...)
+}
+
+// Result returns the current values of the puzzle.
 func (p *Puzzle) Result() ([]int, error) {
-	p.iterationMu.Lock()
-	defer p.iterationMu.Unlock()
-	if p.currentIteration == nil {
+	p.gridMu.Lock()
+	defer p.gridMu.Unlock()
+	if p.grid == nil {
 		return nil, ErrMissingIteration
 	}
-	return p.currentIteration.items(), nil
+	return p.grid.items(), nil
 }
 
 // CompletionRate returns stats on the completion rate of the puzzle.
 func (p *Puzzle) CompletionRate() (*CompletionRate, error) {
-	p.iterationMu.Lock()
-	if p.currentIteration == nil {
-		p.iterationMu.Unlock()
+	p.gridMu.Lock()
+	if p.grid == nil {
+		p.gridMu.Unlock()
 		return nil, ErrMissingIteration
 	}
-	c := p.currentIteration.completionRate()
-	p.iterationMu.Unlock()
+	c := p.grid.completionRate()
+	c.CellIndex = p.index
+	c.MinValueAtCell = p.minValue
+	c.DeducedCells = p.deducedCells
+	c.GuessedCells = p.guessedCells
+	p.gridMu.Unlock()
 
-	c.Completed = c.FilledCells == c.TotalCells
 	p.attemptedIterationsMu.Lock()
 	c.AttemptedIterations = p.attemptedIterations
 	p.attemptedIterationsMu.Unlock()
@@ -230,19 +446,30 @@ func (p *Puzzle) CompletionRate() (*CompletionRate, error) {
 	c.CompletedAt = p.completedAt
 	c.FailedAt = p.failedAt
 	c.StartedAt = p.startedAt
+	c.CancelledAt = p.cancelledAt
 	p.timerMu.Unlock()
-	c.Failed = c.Error != nil
+	// a search may fill every cell before it has checked them, so once a search has started
+	// the puzzle is only complete when it has found a solution.
+	c.Completed = !c.CompletedAt.IsZero() || (c.StartedAt.IsZero() && c.FilledCells == c.TotalCells)
+	c.Cancelled = errors.Is(c.Error, context.Canceled) || errors.Is(c.Error, context.DeadlineExceeded)
+	c.TimedOut = errors.Is(c.Error, context.DeadlineExceeded)
+	c.Failed = c.Error != nil && !c.Cancelled
 	return c, nil
 }
 
 // CalculatePuzzleSize calculates the size of the puzzle.
 // The puzzle size is the entire width of the puzzle.
+// ErrInvalidPuzzleSize is returned if the number of items is not a square number.
 func CalculatePuzzleSize(items []int) (int, error) {
-	puzzleSize := int(math.Sqrt(float64(len(items))))
+	puzzleSize, ok := integerSqrt(len(items))
+	if !ok || puzzleSize == 0 {
+		return 0, fmt.Errorf("%w: %d items do not make a square puzzle", ErrInvalidPuzzleSize, len(items))
+	}
 	return puzzleSize, nil
 }
 
 // CalculateSectionSize calculates the size each section in the puzzle.
+// ErrInvalidPuzzleSize is returned if the puzzle size is not a square number.
 func CalculateSectionSize(items []int, puzzleSize int) (int, error) {
 	if puzzleSize == 0 {
 		var err error
@@ -251,10 +478,26 @@ func CalculateSectionSize(items []int, puzzleSize int) (int, error) {
 			return 0, err
 		}
 	}
-	sectionSize := int(math.Sqrt(float64(puzzleSize)))
+	sectionSize, ok := integerSqrt(puzzleSize)
+	if !ok {
+		return 0, fmt.Errorf("%w: a puzzle size of %d cannot be split into square sections", ErrInvalidPuzzleSize, puzzleSize)
+	}
 	return sectionSize, nil
 }
 
+// integerSqrt returns the square root of n, and false if n is not a square number.
+func integerSqrt(n int) (int, bool) {
+	root := int(math.Sqrt(float64(n)))
+	// correct for any floating point error.
+	for root*root > n {
+		root--
+	}
+	for (root+1)*(root+1) <= n {
+		root++
+	}
+	return root, root*root == n
+}
+
 // FormatPuzzle returns the given items as an [][]int
 // so as you can easily print the results.
 // Input: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16,}
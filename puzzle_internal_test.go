@@ -23,13 +23,13 @@ func ATestNewPuzzle(t *testing.T) {
 		return
 	}
 
-	if p.lastIteration != nil {
-		t.Errorf("last iteration should not be set")
+	if len(p.trail) != 0 {
+		t.Errorf("trail should be empty")
 		return
 	}
 
-	if p.currentIteration == nil {
-		t.Errorf("last iteration not set")
+	if p.grid == nil {
+		t.Errorf("grid not set")
 		return
 	}
 
@@ -47,7 +47,7 @@ func ATestNewPuzzle(t *testing.T) {
 		}
 
 		for i, exp := range expRows {
-			got := p.currentIteration.rows[i]
+			got := p.grid.rows[i]
 			if !reflect.DeepEqual(exp, got) {
 				t.Errorf("exp: %v, got %v", exp, got)
 				return
@@ -69,7 +69,7 @@ func ATestNewPuzzle(t *testing.T) {
 		}
 
 		for i, exp := range expColumns {
-			got := p.currentIteration.columns[i]
+			got := p.grid.columns[i]
 			if !reflect.DeepEqual(exp, got) {
 				t.Errorf("exp: %v, got %v", exp, got)
 				return
@@ -91,7 +91,7 @@ func ATestNewPuzzle(t *testing.T) {
 		}
 
 		for i, exp := range expSections {
-			got := p.currentIteration.sections[i]
+			got := p.grid.sections[i]
 			if !reflect.DeepEqual(exp, got) {
 				t.Errorf("exp: %v, got %v", exp, got)
 				return
@@ -99,3 +99,100 @@ func ATestNewPuzzle(t *testing.T) {
 		}
 	})
 }
+
+func TestPuzzle_Revert(t *testing.T) {
+	p, err := NewPuzzle([]int{
+		0, 0, 0, 3,
+		0, 0, 0, 2,
+		3, 0, 0, 0,
+		4, 0, 0, 0,
+	}, WithPropagation(false))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+
+	p.next(p.grid.cells[0], 1)
+	p.next(p.grid.cells[1], 2)
+	p.next(p.grid.cells[2], 4)
+
+	// cell 2 has no values above 4 left to try, so both it and cell 1 should be reverted.
+	if err := p.revert(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if exp, got := 1, len(p.trail); exp != got {
+		t.Errorf("expected trail length %d, got %d", exp, got)
+	}
+	if exp, got := 1, p.index; exp != got {
+		t.Errorf("expected index %d, got %d", exp, got)
+	}
+	if exp, got := 3, p.minValue; exp != got {
+		t.Errorf("expected min value %d, got %d", exp, got)
+	}
+	exp := []int{
+		1, 0, 0, 3,
+		0, 0, 0, 2,
+		3, 0, 0, 0,
+		4, 0, 0, 0,
+	}
+	if got := p.grid.items(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("exp: %v, got %v", exp, got)
+	}
+
+	if err := p.revert(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if err := p.revert(); err != ErrMissingIteration {
+		t.Errorf("expected %v, got %v", ErrMissingIteration, err)
+	}
+}
+
+func TestPuzzle_Revert_Deductions(t *testing.T) {
+	p, err := NewPuzzle([]int{
+		0, 0, 0, 3,
+		0, 0, 0, 2,
+		3, 0, 0, 0,
+		4, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+
+	// guessing 2 in the first cell allows the rest of the puzzle to be deduced.
+	if !p.next(p.grid.cells[0], 2) {
+		t.Errorf("expected guess to be consistent")
+		return
+	}
+	if exp, got := 1, p.guessedCells; exp != got {
+		t.Errorf("expected %d guessed cells, got %d", exp, got)
+	}
+	if p.deducedCells == 0 {
+		t.Errorf("expected some deduced cells")
+	}
+
+	if err := p.revert(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if exp, got := 0, len(p.trail); exp != got {
+		t.Errorf("expected trail length %d, got %d", exp, got)
+	}
+	if p.deducedCells != 0 || p.guessedCells != 0 {
+		t.Errorf("expected no deduced or guessed cells, got %d and %d", p.deducedCells, p.guessedCells)
+	}
+	exp := []int{
+		0, 0, 0, 3,
+		0, 0, 0, 2,
+		3, 0, 0, 0,
+		4, 0, 0, 0,
+	}
+	if got := p.grid.items(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("exp: %v, got %v", exp, got)
+	}
+	if exp, got := 3, p.minValue; exp != got {
+		t.Errorf("expected min value %d, got %d", exp, got)
+	}
+}
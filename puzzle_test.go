@@ -1,6 +1,8 @@
 package sudoku
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
@@ -140,10 +142,10 @@ func TestPuzzle_CompletionRate(t *testing.T) {
 	}, time.Second*5))*/
 }
 
-func benchmarkPuzzle(input []int) func(b *testing.B) {
+func benchmarkPuzzle(input []int, opts ...Option) func(b *testing.B) {
 	return func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			p, err := NewPuzzle(input)
+			p, err := NewPuzzle(input, opts...)
 			if err != nil {
 				b.Errorf("could not create puzzle: %s", err)
 				return
@@ -176,6 +178,385 @@ func BenchmarkPuzzle_Solve(b *testing.B) {
 	}))
 }
 
+// sparseTopPuzzle is designed to be slow to solve by filling cells in index order.
+var sparseTopPuzzle = []int{
+	0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 3, 0, 8, 5,
+	0, 0, 1, 0, 2, 0, 0, 0, 0,
+	0, 0, 0, 5, 0, 7, 0, 0, 0,
+	0, 0, 4, 0, 0, 0, 1, 0, 0,
+	0, 9, 0, 0, 0, 0, 0, 0, 0,
+	5, 0, 0, 0, 0, 0, 0, 7, 3,
+	0, 0, 2, 0, 1, 0, 0, 0, 0,
+	0, 0, 0, 0, 4, 0, 0, 0, 9,
+}
+
+// hardPuzzle cannot be solved using naked and hidden singles alone.
+var hardPuzzle = []int{
+	1, 0, 0, 0, 0, 7, 0, 9, 0,
+	0, 3, 0, 0, 2, 0, 0, 0, 8,
+	0, 0, 9, 6, 0, 0, 5, 0, 0,
+	0, 0, 5, 3, 0, 0, 9, 0, 0,
+	0, 1, 0, 0, 8, 0, 0, 0, 2,
+	6, 0, 0, 0, 0, 4, 0, 0, 0,
+	3, 0, 0, 0, 0, 0, 0, 1, 0,
+	0, 4, 0, 0, 0, 0, 0, 0, 7,
+	0, 0, 7, 0, 0, 0, 3, 0, 0,
+}
+
+func BenchmarkPuzzle_Solve_CellOrder(b *testing.B) {
+	for _, order := range []CellOrder{LinearCellOrder, MinimumRemainingValuesCellOrder} {
+		b.Run("9x9/"+order.String(), benchmarkPuzzle([]int{
+			6, 0, 0, 0, 0, 0, 1, 5, 0,
+			9, 5, 4, 7, 1, 0, 0, 8, 0,
+			0, 0, 0, 5, 0, 2, 6, 0, 0,
+			8, 0, 0, 0, 9, 4, 0, 0, 6,
+			0, 0, 3, 8, 0, 5, 4, 0, 0,
+			4, 0, 0, 3, 7, 0, 0, 0, 8,
+			0, 0, 6, 9, 0, 3, 0, 0, 0,
+			0, 2, 0, 0, 4, 7, 8, 9, 3,
+			0, 4, 9, 0, 0, 0, 0, 0, 5,
+		}, WithCellOrder(order)))
+	}
+	// the linear cell order takes several seconds to solve this puzzle, so it's left out.
+	b.Run("9x9 sparse top/"+MinimumRemainingValuesCellOrder.String(),
+		benchmarkPuzzle(sparseTopPuzzle, WithCellOrder(MinimumRemainingValuesCellOrder), WithPropagation(false)))
+}
+
+func BenchmarkPuzzle_Solve_Propagation(b *testing.B) {
+	for _, order := range []CellOrder{LinearCellOrder, MinimumRemainingValuesCellOrder} {
+		for _, propagation := range []bool{true, false} {
+			b.Run(fmt.Sprintf("9x9 hard/%s/propagation=%v", order, propagation),
+				benchmarkPuzzle(hardPuzzle, WithCellOrder(order), WithPropagation(propagation)))
+		}
+	}
+}
+
+func TestPuzzle_Solve_MinimumRemainingValues(t *testing.T) {
+	p, err := NewPuzzle(sparseTopPuzzle, WithCellOrder(MinimumRemainingValuesCellOrder), WithPropagation(false))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+	got, err := p.Result()
+	if err != nil {
+		t.Errorf("could not get result: %s", err)
+		return
+	}
+	exp := []int{
+		9, 8, 7, 6, 5, 4, 3, 2, 1,
+		2, 4, 6, 1, 7, 3, 9, 8, 5,
+		3, 5, 1, 9, 2, 8, 7, 4, 6,
+		1, 2, 8, 5, 3, 7, 6, 9, 4,
+		6, 3, 4, 8, 9, 2, 1, 5, 7,
+		7, 9, 5, 4, 6, 1, 8, 3, 2,
+		5, 1, 9, 2, 8, 6, 4, 7, 3,
+		4, 7, 2, 3, 1, 9, 5, 6, 8,
+		8, 6, 3, 7, 4, 5, 2, 1, 9,
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestPuzzle_Solve_Propagation(t *testing.T) {
+	t.Run("NoGuesses", func(t *testing.T) {
+		p, err := NewPuzzle([]int{
+			6, 0, 0, 0, 0, 0, 1, 5, 0,
+			9, 5, 4, 7, 1, 0, 0, 8, 0,
+			0, 0, 0, 5, 0, 2, 6, 0, 0,
+			8, 0, 0, 0, 9, 4, 0, 0, 6,
+			0, 0, 3, 8, 0, 5, 4, 0, 0,
+			4, 0, 0, 3, 7, 0, 0, 0, 8,
+			0, 0, 6, 9, 0, 3, 0, 0, 0,
+			0, 2, 0, 0, 4, 7, 8, 9, 3,
+			0, 4, 9, 0, 0, 0, 0, 0, 5,
+		})
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if err := p.Solve(); err != nil {
+			t.Errorf("could not solve puzzle: %s", err)
+			return
+		}
+		completionRate, err := p.CompletionRate()
+		if err != nil {
+			t.Errorf("could not get completion rate: %s", err)
+			return
+		}
+		if exp, got := 0, completionRate.GuessedCells; exp != got {
+			t.Errorf("expected %d guessed cells, got %d", exp, got)
+		}
+		if exp, got := completionRate.TotalCells-completionRate.FixedCells, completionRate.DeducedCells; exp != got {
+			t.Errorf("expected %d deduced cells, got %d", exp, got)
+		}
+		if exp, got := 1, completionRate.AttemptedIterations; exp != got {
+			t.Errorf("expected %d attempted iterations, got %d", exp, got)
+		}
+	})
+
+	t.Run("Guesses", func(t *testing.T) {
+		p, err := NewPuzzle(hardPuzzle)
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if err := p.Solve(); err != nil {
+			t.Errorf("could not solve puzzle: %s", err)
+			return
+		}
+		completionRate, err := p.CompletionRate()
+		if err != nil {
+			t.Errorf("could not get completion rate: %s", err)
+			return
+		}
+		if completionRate.GuessedCells == 0 {
+			t.Errorf("expected some guessed cells")
+		}
+		if exp, got := completionRate.TotalCells-completionRate.FixedCells,
+			completionRate.DeducedCells+completionRate.GuessedCells; exp != got {
+			t.Errorf("expected %d deduced and guessed cells, got %d", exp, got)
+		}
+	})
+
+	t.Run("Unsolvable", func(t *testing.T) {
+		// the top left cell can only be 1, which leaves nothing for the cell below it.
+		p, err := NewPuzzle([]int{
+			0, 2, 0, 0,
+			0, 0, 3, 4,
+			3, 0, 0, 0,
+			4, 0, 0, 0,
+		})
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if err := p.Solve(); !errors.Is(err, ErrMissingIteration) {
+			t.Errorf("expected error %v, got %v", ErrMissingIteration, err)
+		}
+	})
+}
+
+// sixteenPuzzle is a 16x16 puzzle.
+var sixteenPuzzle = []int{
+	8, 0, 9, 0, 10, 0, 0, 12, 16, 0, 15, 0, 0, 0, 0, 4,
+	0, 0, 15, 0, 0, 0, 5, 0, 0, 7, 0, 10, 0, 0, 13, 0,
+	2, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 16, 0, 14,
+	0, 6, 0, 3, 0, 0, 14, 0, 0, 0, 11, 0, 9, 0, 0, 0,
+	0, 0, 0, 0, 0, 9, 0, 0, 1, 0, 0, 2, 0, 0, 0, 7,
+	11, 0, 0, 0, 14, 0, 0, 6, 0, 0, 0, 0, 0, 13, 0, 0,
+	0, 7, 0, 16, 0, 2, 0, 0, 12, 0, 0, 0, 0, 0, 1, 0,
+	0, 0, 0, 5, 8, 0, 0, 13, 0, 3, 0, 0, 0, 0, 0, 9,
+	12, 0, 4, 0, 0, 0, 7, 0, 13, 0, 8, 0, 11, 0, 0, 0,
+	0, 5, 0, 8, 0, 13, 0, 10, 0, 16, 0, 4, 0, 0, 0, 0,
+	0, 0, 14, 0, 0, 0, 9, 0, 6, 0, 0, 11, 0, 1, 0, 3,
+	1, 0, 0, 0, 3, 0, 0, 5, 0, 0, 0, 0, 6, 0, 16, 0,
+	14, 0, 7, 0, 0, 0, 10, 0, 0, 12, 0, 9, 0, 8, 2, 0,
+	0, 16, 0, 12, 0, 14, 0, 3, 0, 0, 0, 0, 13, 0, 0, 11,
+	5, 10, 0, 0, 6, 0, 11, 0, 0, 0, 14, 0, 0, 7, 0, 0,
+	15, 0, 1, 0, 0, 0, 0, 2, 0, 0, 0, 5, 0, 0, 0, 12,
+}
+
+// checkSolution checks that got is a valid solution for the given input, using square sections.
+func checkSolution(t *testing.T, in []int, got []int) {
+	t.Helper()
+	if len(in) != len(got) {
+		t.Errorf("expected %d items, got %d", len(in), len(got))
+		return
+	}
+	puzzleSize, _ := CalculatePuzzleSize(in)
+	sectionSize, _ := CalculateSectionSize(in, puzzleSize)
+	rows := map[[2]int]bool{}
+	columns := map[[2]int]bool{}
+	sections := map[[2]int]bool{}
+	for i, v := range got {
+		if in[i] != 0 && in[i] != v {
+			t.Errorf("index %d: expected given %d to be kept, got %d", i, in[i], v)
+		}
+		if v < 1 || v > puzzleSize {
+			t.Errorf("index %d: invalid value %d", i, v)
+		}
+		row, column := i/puzzleSize, i%puzzleSize
+		section := (row/sectionSize)*sectionSize + column/sectionSize
+		for _, unit := range []struct {
+			Seen  map[[2]int]bool
+			Index int
+			Name  string
+		}{{rows, row, "row"}, {columns, column, "column"}, {sections, section, "section"}} {
+			if unit.Seen[[2]int{unit.Index, v}] {
+				t.Errorf("index %d: value %d repeated in %s %d", i, v, unit.Name, unit.Index)
+			}
+			unit.Seen[[2]int{unit.Index, v}] = true
+		}
+	}
+}
+
+func TestPuzzle_Solve_DancingLinks(t *testing.T) {
+	for name, in := range map[string][]int{"9x9 hard": hardPuzzle, "9x9 sparse top": sparseTopPuzzle, "16x16": sixteenPuzzle} {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewPuzzle(in, WithAlgorithm(DancingLinksAlgorithm))
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			checkSolution(t, in, got)
+
+			completionRate, err := p.CompletionRate()
+			if err != nil {
+				t.Errorf("could not get completion rate: %s", err)
+				return
+			}
+			if !completionRate.Completed {
+				t.Errorf("expected puzzle to be completed")
+			}
+		})
+	}
+
+	t.Run("Unsolvable", func(t *testing.T) {
+		p, err := NewPuzzle([]int{
+			0, 2, 0, 0,
+			0, 0, 3, 4,
+			3, 0, 0, 0,
+			4, 0, 0, 0,
+		}, WithAlgorithm(DancingLinksAlgorithm))
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if err := p.Solve(); !errors.Is(err, ErrMissingIteration) {
+			t.Errorf("expected error %v, got %v", ErrMissingIteration, err)
+		}
+	})
+}
+
+func TestPuzzle_Solve_DancingLinks_ForcedByConstraint(t *testing.T) {
+	// the empty cells of the top rows could be 1 or 3, and those of the bottom rows 2 or 4,
+	// within their rows, columns and sections. The edge only allows 1 in the first cell, and once it is placed
+	// the edge also rules out 4 in the ninth cell, so no cell needs a guess.
+	p, err := NewPuzzle([]int{
+		0, 2, 0, 4,
+		0, 4, 0, 2,
+		0, 1, 0, 3,
+		0, 3, 0, 1,
+	}, WithAlgorithm(DancingLinksAlgorithm), WithEdges(&Edge{Relation: Double, A: 8, B: 0}))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+	completionRate, err := p.CompletionRate()
+	if err != nil {
+		t.Errorf("could not get completion rate: %s", err)
+		return
+	}
+	if exp, got := 0, completionRate.GuessedCells; exp != got {
+		t.Errorf("expected %d guessed cells, got %d", exp, got)
+	}
+	if exp, got := 8, completionRate.DeducedCells; exp != got {
+		t.Errorf("expected %d deduced cells, got %d", exp, got)
+	}
+}
+
+func BenchmarkPuzzle_Solve_Algorithm(b *testing.B) {
+	for _, algorithm := range []Algorithm{BacktrackingAlgorithm, DancingLinksAlgorithm} {
+		b.Run("9x9 hard/"+algorithm.String(), benchmarkPuzzle(hardPuzzle, WithAlgorithm(algorithm)))
+		b.Run("16x16/"+algorithm.String(), benchmarkPuzzle(sixteenPuzzle, WithAlgorithm(algorithm)))
+	}
+}
+
+func TestNewPuzzleWithSectionSize(t *testing.T) {
+	in := []int{
+		0, 2, 0, 0, 0, 0,
+		4, 0, 6, 0, 0, 3,
+		0, 3, 0, 0, 0, 4,
+		5, 0, 0, 0, 3, 0,
+		3, 0, 0, 6, 0, 5,
+		0, 4, 0, 0, 1, 0,
+	}
+	exp := []int{
+		1, 2, 3, 4, 5, 6,
+		4, 5, 6, 1, 2, 3,
+		2, 3, 1, 5, 6, 4,
+		5, 6, 4, 2, 3, 1,
+		3, 1, 2, 6, 4, 5,
+		6, 4, 5, 3, 1, 2,
+	}
+
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzleWithSectionSize(in, 3, 2, config.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+			count, err := p.CountSolutions(0)
+			if err != nil {
+				t.Errorf("could not count solutions: %s", err)
+				return
+			}
+			if count != 1 {
+				t.Errorf("expected 1 solution, got %d", count)
+			}
+		})
+	}
+
+	t.Run("Conflict", func(t *testing.T) {
+		// 1 and 1 share the first 3x2 section but would not share a square section.
+		_, err := NewPuzzleWithSectionSize([]int{
+			1, 0, 0, 0, 0, 0,
+			0, 0, 1, 0, 0, 0,
+			0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0,
+		}, 3, 2)
+		var conflict *ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("expected *ConflictError, got %v", err)
+			return
+		}
+		if conflict.Unit != "section" || conflict.Cell.Section != 0 {
+			t.Errorf("unexpected conflict: %+v", conflict)
+		}
+	})
+
+	t.Run("InvalidSectionSize", func(t *testing.T) {
+		_, err := NewPuzzleWithSectionSize(make([]int, 36), 4, 2)
+		if !errors.Is(err, ErrInvalidPuzzleSize) {
+			t.Errorf("expected error %v, got %v", ErrInvalidPuzzleSize, err)
+		}
+	})
+}
+
 func ExamplePuzzle_Solve() {
 	input := []int{
 		0, 0, 0, 3,
@@ -215,29 +596,46 @@ func ExamplePuzzle_Solve() {
 	// 4 2 3 1
 }
 
+// solveConfigs contains the different ways a puzzle can be solved.
+// Every config should produce the same result for puzzles with a single solution.
+var solveConfigs = []struct {
+	Name    string
+	Options []Option
+}{
+	{Name: "linear", Options: []Option{WithCellOrder(LinearCellOrder)}},
+	{Name: "linear/propagation=false", Options: []Option{WithCellOrder(LinearCellOrder), WithPropagation(false)}},
+	{Name: "mrv", Options: []Option{WithCellOrder(MinimumRemainingValuesCellOrder)}},
+	{Name: "mrv/propagation=false", Options: []Option{WithCellOrder(MinimumRemainingValuesCellOrder), WithPropagation(false)}},
+	{Name: "dlx", Options: []Option{WithAlgorithm(DancingLinksAlgorithm)}},
+}
+
 func TestPuzzle_Solve(t *testing.T) {
 	run := func(in []int, exp []int) func(*testing.T) {
 		return func(t *testing.T) {
-			p, err := NewPuzzle(in)
-			if err != nil {
-				t.Errorf("could not create new puzzle: %s", err)
-				return
-			}
+			for _, config := range solveConfigs {
+				t.Run(config.Name, func(t *testing.T) {
+					p, err := NewPuzzle(in, config.Options...)
+					if err != nil {
+						t.Errorf("could not create new puzzle: %s", err)
+						return
+					}
 
-			if err := p.Solve(); err != nil {
-				t.Errorf("could not solve puzzle: %s", err)
-				return
-			}
+					if err := p.Solve(); err != nil {
+						t.Errorf("could not solve puzzle: %s", err)
+						return
+					}
 
-			got, err := p.Result()
-			if err != nil {
-				t.Errorf("could not get result: %s", err)
-				return
-			}
+					got, err := p.Result()
+					if err != nil {
+						t.Errorf("could not get result: %s", err)
+						return
+					}
 
-			if !reflect.DeepEqual(exp, got) {
-				t.Errorf("expected %v, got %v", exp, got)
-				return
+					if !reflect.DeepEqual(exp, got) {
+						t.Errorf("expected %v, got %v", exp, got)
+						return
+					}
+				})
 			}
 		}
 	}
@@ -398,6 +796,266 @@ func TestPuzzle_Solve(t *testing.T) {
 	})
 }
 
+func TestPuzzle_Solve_Again(t *testing.T) {
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			exp := solve(t, hardPuzzle, config.Options...)
+			p, err := NewPuzzle(hardPuzzle, config.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			// solving again should keep the solution rather than look for another one.
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle again: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+			completionRate, err := p.CompletionRate()
+			if err != nil {
+				t.Errorf("could not get completion rate: %s", err)
+				return
+			}
+			if !completionRate.Completed || completionRate.Failed {
+				t.Errorf("expected puzzle to be completed, got %+v", completionRate)
+			}
+		})
+	}
+}
+
+// checkDeadline is a context whose deadline is exceeded once Done has been called a given number of times,
+// such as once per step of a search.
+type checkDeadline struct {
+	context.Context
+	remaining int
+	done      chan struct{}
+}
+
+func (c *checkDeadline) Done() <-chan struct{} {
+	if c.remaining == 0 {
+		close(c.done)
+	}
+	c.remaining--
+	return c.done
+}
+
+func (c *checkDeadline) Err() error {
+	if c.remaining < 0 {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestPuzzle_SolveContext(t *testing.T) {
+	input := []int{
+		6, 0, 0, 0, 0, 0, 1, 5, 0,
+		9, 5, 4, 7, 1, 0, 0, 8, 0,
+		0, 0, 0, 5, 0, 2, 6, 0, 0,
+		8, 0, 0, 0, 9, 4, 0, 0, 6,
+		0, 0, 3, 8, 0, 5, 4, 0, 0,
+		4, 0, 0, 3, 7, 0, 0, 0, 8,
+		0, 0, 6, 9, 0, 3, 0, 0, 0,
+		0, 2, 0, 0, 4, 7, 8, 9, 3,
+		0, 4, 9, 0, 0, 0, 0, 0, 5,
+	}
+
+	run := func(ctx context.Context, expErr error, expTimedOut bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			p, err := NewPuzzle(input)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+
+			err = p.SolveContext(ctx)
+			if !errors.Is(err, expErr) {
+				t.Errorf("expected error %v, got %v", expErr, err)
+				return
+			}
+
+			completionRate, err := p.CompletionRate()
+			if err != nil {
+				t.Errorf("could not get completion rate: %s", err)
+				return
+			}
+			if !completionRate.Cancelled {
+				t.Errorf("expected puzzle to be cancelled")
+			}
+			if completionRate.Failed {
+				t.Errorf("expected puzzle not to be failed")
+			}
+			if completionRate.Completed {
+				t.Errorf("expected puzzle not to be completed")
+			}
+			if completionRate.TimedOut != expTimedOut {
+				t.Errorf("expected timed out %v, got %v", expTimedOut, completionRate.TimedOut)
+			}
+			if completionRate.CancelledAt.IsZero() {
+				t.Errorf("expected cancelled at to be set")
+			}
+
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if len(got) != len(input) {
+				t.Errorf("expected %d items, got %d", len(input), len(got))
+			}
+		}
+	}
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		run(ctx, context.Canceled, false)(t)
+	})
+	t.Run("DeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		run(ctx, context.DeadlineExceeded, true)(t)
+	})
+	t.Run("DeadlineDuringSearch", func(t *testing.T) {
+		// without propagation the sparse puzzle takes thousands of steps to solve, so the deadline passes during the search.
+		p, err := NewPuzzle(sparseTopPuzzle, WithCellOrder(MinimumRemainingValuesCellOrder), WithPropagation(false))
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		// a real timer could fire late on a busy machine, so the deadline passes after a fixed number of checks instead.
+		ctx := &checkDeadline{Context: context.Background(), remaining: 100, done: make(chan struct{})}
+		if err := p.SolveContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected error %v, got %v", context.DeadlineExceeded, err)
+			return
+		}
+
+		completionRate, err := p.CompletionRate()
+		if err != nil {
+			t.Errorf("could not get completion rate: %s", err)
+			return
+		}
+		if !completionRate.Cancelled || !completionRate.TimedOut || completionRate.Completed || completionRate.Failed {
+			t.Errorf("expected puzzle to have timed out, got %+v", completionRate)
+		}
+		if completionRate.FilledCells == completionRate.FixedCells {
+			t.Errorf("expected the search to have filled some cells before timing out")
+		}
+
+		// the partial grid keeps the givens and has no conflicts.
+		got, err := p.Result()
+		if err != nil {
+			t.Errorf("could not get result: %s", err)
+			return
+		}
+		for index, value := range sparseTopPuzzle {
+			if value != 0 && got[index] != value {
+				t.Errorf("expected given %d in cell %d, got %d", value, index, got[index])
+			}
+		}
+		if err := newGrid(got, p.layout).validate(); err != nil {
+			t.Errorf("expected a consistent partial grid, got %s", err)
+		}
+
+		// solving again resumes the search and finishes.
+		if err := p.Solve(); err != nil {
+			t.Errorf("could not solve puzzle: %s", err)
+			return
+		}
+		if completionRate, err = p.CompletionRate(); err != nil || !completionRate.Completed || completionRate.Cancelled {
+			t.Errorf("expected puzzle to be completed, got %+v, %v", completionRate, err)
+		}
+		got, err = p.Result()
+		if err != nil {
+			t.Errorf("could not get result: %s", err)
+			return
+		}
+		if exp := solve(t, sparseTopPuzzle); !reflect.DeepEqual(exp, got) {
+			t.Errorf("expected %v, got %v", exp, got)
+		}
+	})
+}
+
+func TestPuzzle_SolveContext_Resume(t *testing.T) {
+	p, err := NewPuzzle(hardPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.SolveContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+		return
+	}
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+
+	completionRate, err := p.CompletionRate()
+	if err != nil {
+		t.Errorf("could not get completion rate: %s", err)
+		return
+	}
+	if !completionRate.Completed {
+		t.Errorf("expected puzzle to be completed")
+	}
+	if completionRate.Cancelled || completionRate.TimedOut || completionRate.Failed {
+		t.Errorf("expected puzzle not to be cancelled or failed, got %+v", completionRate)
+	}
+	if completionRate.Error != nil {
+		t.Errorf("expected no error, got %v", completionRate.Error)
+	}
+	if !completionRate.CancelledAt.IsZero() || !completionRate.FailedAt.IsZero() {
+		t.Errorf("expected cancelled at and failed at to be cleared")
+	}
+}
+
+func TestPuzzle_CompletionRate_Completed(t *testing.T) {
+	// every cell is given, so the puzzle is complete before it has been solved.
+	p, err := NewPuzzle([]int{
+		2, 4, 1, 3,
+		1, 3, 4, 2,
+		3, 1, 2, 4,
+		4, 2, 3, 1,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	completionRate, err := p.CompletionRate()
+	if err != nil {
+		t.Errorf("could not get completion rate: %s", err)
+		return
+	}
+	if !completionRate.Completed {
+		t.Errorf("expected puzzle to be completed before solving")
+	}
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+	completionRate, err = p.CompletionRate()
+	if err != nil {
+		t.Errorf("could not get completion rate: %s", err)
+		return
+	}
+	if !completionRate.Completed {
+		t.Errorf("expected puzzle to be completed after solving")
+	}
+}
+
 func testCalculatePuzzleSize(input []int, exp int) func(t *testing.T) {
 	return func(t *testing.T) {
 		got, err := CalculatePuzzleSize(input)
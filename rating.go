@@ -0,0 +1,167 @@
+package sudoku
+
+import "errors"
+
+// Difficulty is how hard a puzzle is for a person to solve.
+type Difficulty int
+
+const (
+	// Easy puzzles can be solved using naked and hidden singles alone.
+	Easy Difficulty = iota
+	// Medium puzzles also need pointing pairs, box/line reduction, naked and hidden pairs or X-Wings.
+	Medium
+	// Hard puzzles also need triples, Swordfish, XY-Wings or XYZ-Wings.
+	Hard
+	// Expert puzzles also need unique rectangles, simple colouring, quads or Jellyfish.
+	Expert
+	// Diabolical puzzles cannot be solved using any of the techniques, so need guessing.
+	Diabolical
+)
+
+// String returns the name of the difficulty.
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	case Hard:
+		return "hard"
+	case Expert:
+		return "expert"
+	case Diabolical:
+		return "diabolical"
+	default:
+		return "unknown"
+	}
+}
+
+// maxAdvancedSteps is the number of steps using techniques harder than singles a puzzle can need
+// before it is rated one difficulty higher than its hardest technique.
+const maxAdvancedSteps = 5
+
+// Score returns the score of the technique on a scale similar to Sudoku Explainer,
+// from 1.5 for a hidden single up to 10 for a guess.
+func (t Technique) Score() float64 {
+	switch t {
+	case HiddenSingle:
+		return 1.5
+	case NakedSingle:
+		return 2.3
+	case PointingPair:
+		return 2.6
+	case BoxLineReduction:
+		return 2.8
+	case NakedPair:
+		return 3.0
+	case XWing:
+		return 3.2
+	case HiddenPair:
+		return 3.4
+	case NakedTriple:
+		return 3.6
+	case Swordfish:
+		return 3.8
+	case HiddenTriple:
+		return 4.0
+	case XYWing:
+		return 4.2
+	case XYZWing:
+		return 4.4
+	case UniqueRectangle:
+		return 4.5
+	case SimpleColouring:
+		return 4.6
+	case NakedQuad:
+		return 5.0
+	case Jellyfish:
+		return 5.2
+	case HiddenQuad:
+		return 5.4
+	default:
+		return 10
+	}
+}
+
+// difficulty returns the difficulty of a puzzle that needs the technique.
+func (t Technique) difficulty() Difficulty {
+	switch {
+	case t <= NakedSingle:
+		return Easy
+	case t <= HiddenPair:
+		return Medium
+	case t <= XYZWing:
+		return Hard
+	case t < Guess:
+		return Expert
+	default:
+		return Diabolical
+	}
+}
+
+// Rating describes how hard a puzzle is for a person to solve, based on the steps the logical solver needs.
+type Rating struct {
+	Difficulty Difficulty
+	// Score is the score of the hardest technique needed, see Technique.Score.
+	Score float64
+	// Hardest is the hardest technique needed.
+	Hardest Technique
+	// Steps is the number of steps needed to solve the puzzle, including guesses.
+	Steps int
+	// Techniques holds the number of steps using each technique.
+	Techniques map[Technique]int
+}
+
+// Rate returns the difficulty of solving the puzzle from its givens using the logical solver.
+//
+// The difficulty comes from the hardest technique needed, and is one higher if more than five steps
+// need techniques harder than singles, up to Expert. Puzzles that cannot be solved without guessing are
+// always Diabolical, and each guess places the value from the solution in the cell with the fewest candidates
+// before the logical solver carries on.
+//
+// Unique rectangles rely on the puzzle having a single solution, so a *NotUniqueError is returned if it has more
+// than one, and an error wrapping ErrMissingIteration if it has none.
+func (p *Puzzle) Rate() (*Rating, error) {
+	// dancing links copes best with the hard puzzles that need guessing.
+	o := *p.options
+	o.algorithm = DancingLinksAlgorithm
+	solver, err := newPuzzle(p.givens, p.layout, &o)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := solver.HasUniqueSolution(); err != nil {
+		return nil, err
+	}
+
+	r := &Rating{Techniques: make(map[Technique]int)}
+	advanced := 0
+	c := solver.Candidates()
+	for !c.Solved() {
+		s, err := c.Next()
+		if errors.Is(err, ErrNoLogicalStep) {
+			s, err = solver.guess(c)
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.Apply(s)
+		r.Steps++
+		r.Techniques[s.Technique]++
+		if s.Technique > NakedSingle {
+			advanced++
+		}
+		if r.Steps == 1 || s.Technique > r.Hardest {
+			r.Hardest = s.Technique
+		}
+	}
+
+	if r.Steps == 0 {
+		return r, nil
+	}
+	r.Score = r.Hardest.Score()
+	r.Difficulty = r.Hardest.difficulty()
+	if advanced > maxAdvancedSteps && r.Difficulty < Expert {
+		r.Difficulty++
+	}
+	return r, nil
+}
@@ -0,0 +1,184 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// techniquePuzzle returns the puzzle from techniquePuzzles that needs the given technique.
+func techniquePuzzle(t *testing.T, technique Technique) []int {
+	for _, tc := range techniquePuzzles {
+		if tc.Technique == technique {
+			return tc.In
+		}
+	}
+	t.Fatalf("no puzzle for %s", technique)
+	return nil
+}
+
+func TestPuzzle_Rate(t *testing.T) {
+	tests := []struct {
+		Name       string
+		In         []int
+		Difficulty Difficulty
+		Hardest    Technique
+	}{
+		{Name: "Easy", In: sparseTopPuzzle, Difficulty: Easy, Hardest: NakedSingle},
+		{Name: "Medium", In: techniquePuzzle(t, BoxLineReduction), Difficulty: Medium, Hardest: BoxLineReduction},
+		// only needs naked triples, but needs more advanced steps than a hard puzzle.
+		{Name: "ManyAdvancedSteps", In: techniquePuzzle(t, NakedTriple), Difficulty: Expert, Hardest: NakedTriple},
+		{Name: "Hard", In: techniquePuzzle(t, XYWing), Difficulty: Hard, Hardest: XYWing},
+		{Name: "Expert", In: techniquePuzzle(t, NakedQuad), Difficulty: Expert, Hardest: NakedQuad},
+		{Name: "Diabolical", In: hardPuzzle, Difficulty: Diabolical, Hardest: Guess},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			p, err := NewPuzzle(tc.In)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			got, err := p.Rate()
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if got.Difficulty != tc.Difficulty || got.Hardest != tc.Hardest || got.Score != tc.Hardest.Score() {
+				t.Errorf("expected %s with hardest technique %s, got %s with %s scoring %v",
+					tc.Difficulty, tc.Hardest, got.Difficulty, got.Hardest, got.Score)
+			}
+			steps := 0
+			for _, count := range got.Techniques {
+				steps += count
+			}
+			if steps != got.Steps {
+				t.Errorf("expected techniques to add up to %d steps, got %d", got.Steps, steps)
+			}
+		})
+	}
+}
+
+func TestPuzzle_Rate_Steps(t *testing.T) {
+	p, err := NewPuzzle(sparseTopPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	// rating uses the givens, so solving the puzzle first makes no difference.
+	if err := p.Solve(); err != nil {
+		t.Errorf("could not solve puzzle: %s", err)
+		return
+	}
+	got, err := p.Rate()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	exp := &Rating{
+		Difficulty: Easy,
+		Score:      2.3,
+		Hardest:    NakedSingle,
+		Steps:      64,
+		Techniques: map[Technique]int{HiddenSingle: 60, NakedSingle: 4},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestPuzzle_Rate_Guesses(t *testing.T) {
+	p, err := NewPuzzle(hardPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	got, err := p.Rate()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if got.Techniques[Guess] == 0 {
+		t.Errorf("expected at least one guess, got %v", got.Techniques)
+	}
+	if got.Score != 10 {
+		t.Errorf("expected score 10, got %v", got.Score)
+	}
+}
+
+func TestPuzzle_Rate_Solved(t *testing.T) {
+	p, err := NewPuzzle([]int{
+		1, 2, 3, 4,
+		3, 4, 1, 2,
+		2, 1, 4, 3,
+		4, 3, 2, 1,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	got, err := p.Rate()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	exp := &Rating{Difficulty: Easy, Techniques: map[Technique]int{}}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestPuzzle_Rate_NotUnique(t *testing.T) {
+	p, err := NewPuzzle(make([]int, 16))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	var notUnique *NotUniqueError
+	if _, err := p.Rate(); !errors.As(err, &notUnique) {
+		t.Errorf("expected *NotUniqueError, got %v", err)
+	}
+}
+
+func TestPuzzle_Rate_NoSolution(t *testing.T) {
+	p, err := NewPuzzle([]int{
+		1, 2, 0, 0,
+		0, 0, 3, 0,
+		0, 0, 4, 0,
+		0, 0, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	if _, err := p.Rate(); !errors.Is(err, ErrMissingIteration) {
+		t.Errorf("expected error %v, got %v", ErrMissingIteration, err)
+	}
+}
+
+func TestTechnique_Score(t *testing.T) {
+	// techniques are tried from easiest to hardest, so their scores should never go down.
+	for technique := HiddenSingle; technique < Guess; technique++ {
+		if technique.Score() > (technique + 1).Score() {
+			t.Errorf("expected %s to score no more than %s, got %v and %v",
+				technique, technique+1, technique.Score(), (technique + 1).Score())
+		}
+	}
+}
+
+func TestDifficulty_String(t *testing.T) {
+	tests := map[Difficulty]string{
+		Easy:           "easy",
+		Medium:         "medium",
+		Hard:           "hard",
+		Expert:         "expert",
+		Diabolical:     "diabolical",
+		Difficulty(-1): "unknown",
+	}
+	for difficulty, exp := range tests {
+		if got := difficulty.String(); got != exp {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+)
+
+// SolutionIterator iterates over the solutions of a puzzle.
+//
+// Each call to Next continues the search from where the previous solution was found:
+//
+//	it := p.Solutions()
+//	for it.Next() {
+//	    fmt.Println(it.Solution())
+//	}
+//	if err := it.Err(); err != nil {
+//	    ...
+//	}
+type SolutionIterator struct {
+	ctx      context.Context
+	puzzle   *Puzzle
+	solution []int
+	err      error
+	done     bool
+}
+
+// Solutions returns an iterator over every solution of the puzzle.
+// The search runs on a copy of the puzzle so it does not affect Solve or Result.
+func (p *Puzzle) Solutions() *SolutionIterator {
+	return p.SolutionsContext(context.Background())
+}
+
+// SolutionsContext returns an iterator over every solution of the puzzle,
+// stopping with an error if the given context is cancelled or its deadline is exceeded.
+func (p *Puzzle) SolutionsContext(ctx context.Context) *SolutionIterator {
+	it := &SolutionIterator{
+		ctx: ctx,
+	}
+	it.puzzle, it.err = newPuzzle(p.givens, p.layout, p.options)
+	if it.err != nil {
+		it.done = true
+	}
+	return it
+}
+
+// Next searches for the next solution, returning false once there are no more solutions or an error occurs.
+func (it *SolutionIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	// skip the solution found by the previous call.
+	found, err := it.puzzle.search(it.ctx, true)
+	if err != nil || !found {
+		it.err = err
+		it.done = true
+		it.solution = nil
+		return false
+	}
+	it.solution, it.err = it.puzzle.Result()
+	return it.err == nil
+}
+
+// Solution returns the solution found by the most recent call to Next.
+func (it *SolutionIterator) Solution() []int {
+	return it.solution
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *SolutionIterator) Err() error {
+	return it.err
+}
+
+// CountSolutions returns the number of solutions the puzzle has, stopping once limit solutions have been found.
+// A limit of 0 or less counts every solution, which can take a very long time for puzzles with few givens.
+func (p *Puzzle) CountSolutions(limit int) (int, error) {
+	return p.CountSolutionsContext(context.Background(), limit)
+}
+
+// CountSolutionsContext returns the number of solutions the puzzle has, stopping once limit solutions have been found
+// or the given context is cancelled.
+// The number of solutions found before the context was cancelled is returned along with the error.
+func (p *Puzzle) CountSolutionsContext(ctx context.Context, limit int) (int, error) {
+	count := 0
+	it := p.SolutionsContext(ctx)
+	for (limit <= 0 || count < limit) && it.Next() {
+		count++
+	}
+	return count, it.Err()
+}
+
+// NotUniqueError is returned by HasUniqueSolution when a puzzle has more than one solution.
+// It contains two different solutions as evidence.
+type NotUniqueError struct {
+	First  []int
+	Second []int
+}
+
+// Error returns the error message.
+func (e *NotUniqueError) Error() string {
+	for i := range e.First {
+		if e.First[i] != e.Second[i] {
+			return fmt.Sprintf("puzzle has multiple solutions: cell %d can be %d or %d", i, e.First[i], e.Second[i])
+		}
+	}
+	return "puzzle has multiple solutions"
+}
+
+// HasUniqueSolution returns true if the puzzle has exactly one solution.
+// The search stops as soon as a second solution is found, in which case a *NotUniqueError containing
+// both solutions is returned. If the puzzle has no solution an error wrapping ErrMissingIteration is returned.
+func (p *Puzzle) HasUniqueSolution() (bool, error) {
+	return p.HasUniqueSolutionContext(context.Background())
+}
+
+// HasUniqueSolutionContext returns true if the puzzle has exactly one solution,
+// stopping with an error if the given context is cancelled or its deadline is exceeded.
+// See HasUniqueSolution.
+func (p *Puzzle) HasUniqueSolutionContext(ctx context.Context) (bool, error) {
+	it := p.SolutionsContext(ctx)
+	if !it.Next() {
+		if err := it.Err(); err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("puzzle has no solution: %w", ErrMissingIteration)
+	}
+	first := it.Solution()
+	if !it.Next() {
+		if err := it.Err(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, &NotUniqueError{
+		First:  first,
+		Second: it.Solution(),
+	}
+}
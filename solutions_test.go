@@ -0,0 +1,205 @@
+package sudoku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPuzzle_CountSolutions(t *testing.T) {
+	tests := []struct {
+		Name  string
+		In    []int
+		Limit int
+		Exp   int
+	}{
+		{Name: "Unique", In: hardPuzzle, Exp: 1},
+		{Name: "Empty4x4", In: make([]int, 16), Exp: 288},
+		{Name: "Empty4x4Limit", In: make([]int, 16), Limit: 2, Exp: 2},
+		// every arrangement of the first section has the same number of completions, 288 / 4! = 12.
+		{Name: "Multiple", In: []int{
+			1, 2, 0, 0,
+			3, 4, 0, 0,
+			0, 0, 0, 0,
+			0, 0, 0, 0,
+		}, Exp: 12},
+		{Name: "None", In: []int{
+			0, 2, 0, 0,
+			0, 0, 3, 4,
+			3, 0, 0, 0,
+			4, 0, 0, 0,
+		}, Exp: 0},
+		{Name: "Solved", In: []int{
+			2, 4, 1, 3,
+			1, 3, 4, 2,
+			3, 1, 2, 4,
+			4, 2, 3, 1,
+		}, Exp: 1},
+	}
+
+	for _, tc := range tests {
+		for _, config := range solveConfigs {
+			t.Run(fmt.Sprintf("%s/%s", tc.Name, config.Name), func(t *testing.T) {
+				p, err := NewPuzzle(tc.In, config.Options...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				got, err := p.CountSolutions(tc.Limit)
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				if tc.Exp != got {
+					t.Errorf("expected %d, got %d", tc.Exp, got)
+				}
+			})
+		}
+	}
+}
+
+func TestPuzzle_Solutions(t *testing.T) {
+	in := make([]int, 16)
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(in, config.Options...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+
+			seen := map[string]bool{}
+			it := p.Solutions()
+			for it.Next() {
+				got := it.Solution()
+				checkSolution(t, in, got)
+				key := fmt.Sprint(got)
+				if seen[key] {
+					t.Errorf("solution returned twice: %v", got)
+				}
+				seen[key] = true
+			}
+			if err := it.Err(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if exp, got := 288, len(seen); exp != got {
+				t.Errorf("expected %d solutions, got %d", exp, got)
+			}
+			if it.Next() {
+				t.Errorf("expected iterator to stay finished")
+			}
+
+			// the original puzzle should not have been touched.
+			res, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if fmt.Sprint(in) != fmt.Sprint(res) {
+				t.Errorf("expected puzzle to be unchanged, got %v", res)
+			}
+		})
+	}
+}
+
+func TestPuzzle_SolutionsContext(t *testing.T) {
+	p, err := NewPuzzle(make([]int, 81))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count, err := p.CountSolutionsContext(ctx, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error %v, got %v", context.Canceled, err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 solutions, got %d", count)
+	}
+}
+
+func TestPuzzle_HasUniqueSolution(t *testing.T) {
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			t.Run("Unique", func(t *testing.T) {
+				p, err := NewPuzzle(hardPuzzle, config.Options...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				unique, err := p.HasUniqueSolution()
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				if !unique {
+					t.Errorf("expected puzzle to be unique")
+				}
+			})
+
+			t.Run("Multiple", func(t *testing.T) {
+				in := []int{
+					1, 2, 0, 0,
+					3, 4, 0, 0,
+					0, 0, 0, 0,
+					0, 0, 0, 0,
+				}
+				p, err := NewPuzzle(in, config.Options...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				unique, err := p.HasUniqueSolution()
+				if unique {
+					t.Errorf("expected puzzle not to be unique")
+				}
+				var notUnique *NotUniqueError
+				if !errors.As(err, &notUnique) {
+					t.Errorf("expected *NotUniqueError, got %v", err)
+					return
+				}
+				checkSolution(t, in, notUnique.First)
+				checkSolution(t, in, notUnique.Second)
+				if fmt.Sprint(notUnique.First) == fmt.Sprint(notUnique.Second) {
+					t.Errorf("expected solutions to differ, got %v", notUnique.First)
+				}
+			})
+
+			t.Run("None", func(t *testing.T) {
+				p, err := NewPuzzle([]int{
+					0, 2, 0, 0,
+					0, 0, 3, 4,
+					3, 0, 0, 0,
+					4, 0, 0, 0,
+				}, config.Options...)
+				if err != nil {
+					t.Errorf("could not create new puzzle: %s", err)
+					return
+				}
+				unique, err := p.HasUniqueSolution()
+				if unique {
+					t.Errorf("expected puzzle not to be unique")
+				}
+				if !errors.Is(err, ErrMissingIteration) {
+					t.Errorf("expected error %v, got %v", ErrMissingIteration, err)
+				}
+			})
+		})
+	}
+}
+
+func ExamplePuzzle_CountSolutions() {
+	p, _ := NewPuzzle([]int{
+		1, 2, 0, 0,
+		3, 4, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+	})
+	count, _ := p.CountSolutions(0)
+	fmt.Println(count)
+
+	// Output:
+	// 12
+}
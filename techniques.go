@@ -0,0 +1,766 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Technique is a named logical solving technique, as used by a person solving a puzzle.
+// Techniques are listed from easiest to hardest, which is the order the logical solver tries them in.
+type Technique int
+
+const (
+	// HiddenSingle places a value in the only cell of a region that can hold it.
+	HiddenSingle Technique = iota
+	// NakedSingle places the only candidate of a cell.
+	NakedSingle
+	// PointingPair removes a value from a row, column or other region when every cell of a section
+	// that can hold the value is also in that region. It also covers pointing triples.
+	PointingPair
+	// BoxLineReduction removes a value from a section when every cell of a row, column or other region
+	// that can hold the value is also in that section.
+	BoxLineReduction
+	// NakedPair removes the candidates of two cells in a region that can only hold the same two values
+	// from the other cells of the region.
+	NakedPair
+	// XWing removes a value from two columns when the only cells of two rows that can hold it are in those columns,
+	// or the same with rows and columns swapped.
+	XWing
+	// HiddenPair removes the other candidates of the only two cells of a region that can hold two values.
+	HiddenPair
+	// NakedTriple is a NakedPair with three cells and three values.
+	NakedTriple
+	// Swordfish is an XWing with three rows and three columns.
+	Swordfish
+	// HiddenTriple is a HiddenPair with three cells and three values.
+	HiddenTriple
+	// XYWing removes a value from every cell that sees both wings of a pivot cell with two candidates,
+	// where each wing shares one candidate with the pivot and the value with the other wing.
+	XYWing
+	// XYZWing is an XYWing where the pivot also has the value as a candidate,
+	// so the value is only removed from cells that see the pivot too.
+	XYZWing
+	// UniqueRectangle removes the two candidates shared by the other three cells of a rectangle from its fourth cell,
+	// since placing either would allow the values to be swapped, giving a second solution.
+	// It assumes the puzzle has a unique solution, and is only used by puzzles with no rules
+	// beyond rows, columns and sections.
+	UniqueRectangle
+	// SimpleColouring follows the chains of cells linked by being the only two cells of a region that can hold a value,
+	// alternating between two colours, one of which must hold the value. The value is removed from cells that
+	// see both colours, or from every cell of a colour that sees itself.
+	SimpleColouring
+	// NakedQuad is a NakedPair with four cells and four values.
+	NakedQuad
+	// Jellyfish is an XWing with four rows and four columns.
+	Jellyfish
+	// HiddenQuad is a HiddenPair with four cells and four values.
+	HiddenQuad
+	// Guess is not a logical technique. It places the value from the solution of the puzzle
+	// in the empty cell with the fewest candidates, and is only used by hints when no technique can make progress.
+	Guess
+)
+
+// String returns the name of the technique.
+func (t Technique) String() string {
+	switch t {
+	case HiddenSingle:
+		return "hidden single"
+	case NakedSingle:
+		return "naked single"
+	case PointingPair:
+		return "pointing pair"
+	case BoxLineReduction:
+		return "box/line reduction"
+	case NakedPair:
+		return "naked pair"
+	case XWing:
+		return "x-wing"
+	case HiddenPair:
+		return "hidden pair"
+	case NakedTriple:
+		return "naked triple"
+	case Swordfish:
+		return "swordfish"
+	case HiddenTriple:
+		return "hidden triple"
+	case XYWing:
+		return "xy-wing"
+	case XYZWing:
+		return "xyz-wing"
+	case UniqueRectangle:
+		return "unique rectangle"
+	case SimpleColouring:
+		return "simple colouring"
+	case NakedQuad:
+		return "naked quad"
+	case Jellyfish:
+		return "jellyfish"
+	case HiddenQuad:
+		return "hidden quad"
+	case Guess:
+		return "guess"
+	default:
+		return "unknown"
+	}
+}
+
+// Candidate is a value that may be placed in the cell at Index.
+type Candidate struct {
+	Index int
+	Value int
+}
+
+// String returns a description of the candidate.
+func (c Candidate) String() string {
+	return fmt.Sprintf("%d in cell %d", c.Value, c.Index)
+}
+
+// Step is a single deduction made by the logical solver.
+type Step struct {
+	Technique Technique
+	// Placements holds the values placed by the step. Only singles and guesses place values.
+	Placements []Candidate
+	// Eliminations holds the candidates removed by the step.
+	Eliminations []Candidate
+	// Reasons holds the indexes of the cells the deduction is based on, such as the cells of a naked pair.
+	Reasons []int
+	// Regions holds the regions the deduction is based on, if any.
+	Regions []*Region
+}
+
+// Cells returns the indexes of the cells changed by the step, in the order they first appear.
+func (s *Step) Cells() []int {
+	var res []int
+	for _, p := range s.Placements {
+		if !contains(res, p.Index) {
+			res = append(res, p.Index)
+		}
+	}
+	for _, e := range s.Eliminations {
+		if !contains(res, e.Index) {
+			res = append(res, e.Index)
+		}
+	}
+	return res
+}
+
+// String returns a description of the step.
+func (s *Step) String() string {
+	var changes []string
+	for _, p := range s.Placements {
+		changes = append(changes, fmt.Sprintf("place %d in cell %d", p.Value, p.Index))
+	}
+	for _, e := range s.Eliminations {
+		changes = append(changes, fmt.Sprintf("remove %d from cell %d", e.Value, e.Index))
+	}
+	res := fmt.Sprintf("%s: %s", s.Technique, strings.Join(changes, ", "))
+	if len(s.Reasons) > 0 {
+		res += fmt.Sprintf(" because of cells %v", s.Reasons)
+	}
+	return res
+}
+
+// finders holds the function that finds a step using each technique, in the same order as the techniques.
+// Each function returns nil if the technique cannot make progress.
+var finders = []func(c *Candidates) *Step{
+	findHiddenSingle,
+	findNakedSingle,
+	func(c *Candidates) *Step { return findLockedCandidates(c, true) },
+	func(c *Candidates) *Step { return findLockedCandidates(c, false) },
+	func(c *Candidates) *Step { return findNakedSubset(c, 2) },
+	func(c *Candidates) *Step { return findFish(c, 2) },
+	func(c *Candidates) *Step { return findHiddenSubset(c, 2) },
+	func(c *Candidates) *Step { return findNakedSubset(c, 3) },
+	func(c *Candidates) *Step { return findFish(c, 3) },
+	func(c *Candidates) *Step { return findHiddenSubset(c, 3) },
+	findXYWing,
+	findXYZWing,
+	findUniqueRectangle,
+	findSimpleColouring,
+	func(c *Candidates) *Step { return findNakedSubset(c, 4) },
+	func(c *Candidates) *Step { return findFish(c, 4) },
+	func(c *Candidates) *Step { return findHiddenSubset(c, 4) },
+}
+
+// findHiddenSingle finds a value that can only go in one cell of a region that must contain every value.
+// The reasons are the cells holding the value that see the other empty cells of the region.
+func findHiddenSingle(c *Candidates) *Step {
+	for _, r := range c.fullRegions() {
+		for value := 1; value <= c.Size(); value++ {
+			cells := c.cellsWith(r, value)
+			if len(cells) != 1 {
+				continue
+			}
+			var reasons []int
+			for _, index := range r.Indexes {
+				if index == cells[0] || c.values[index] != 0 {
+					continue
+				}
+				for _, peer := range c.peers(index) {
+					if c.values[peer] == value {
+						if !contains(reasons, peer) {
+							reasons = append(reasons, peer)
+						}
+						break
+					}
+				}
+			}
+			return &Step{
+				Technique:  HiddenSingle,
+				Placements: []Candidate{{Index: cells[0], Value: value}},
+				Reasons:    reasons,
+				Regions:    []*Region{r},
+			}
+		}
+	}
+	return nil
+}
+
+// findNakedSingle finds an empty cell with a single candidate.
+func findNakedSingle(c *Candidates) *Step {
+	for index, candidates := range c.candidates {
+		if candidates.count() != 1 {
+			continue
+		}
+		var reasons []int
+		for _, peer := range c.peers(index) {
+			if c.values[peer] != 0 {
+				reasons = append(reasons, peer)
+			}
+		}
+		return &Step{
+			Technique:  NakedSingle,
+			Placements: []Candidate{{Index: index, Value: candidates.lowest()}},
+			Reasons:    reasons,
+		}
+	}
+	return nil
+}
+
+// findLockedCandidates finds a value whose only cells in a region that must contain every value are also
+// all in another region, so the value can be removed from the rest of the other region.
+// If pointing is true the first region is a section, otherwise it is any other region.
+func findLockedCandidates(c *Candidates, pointing bool) *Step {
+	technique := BoxLineReduction
+	if pointing {
+		technique = PointingPair
+	}
+	for _, r := range c.fullRegions() {
+		if c.isSection(r) != pointing {
+			continue
+		}
+		for value := 1; value <= c.Size(); value++ {
+			cells := c.cellsWith(r, value)
+			if len(cells) < 2 {
+				continue
+			}
+			for _, i := range c.cellRegions[cells[0]] {
+				other := c.regions[i]
+				if other == r || !containsAll(other.Indexes, cells) {
+					continue
+				}
+				var eliminations []Candidate
+				for _, index := range c.cellsWith(other, value) {
+					if !contains(r.Indexes, index) {
+						eliminations = append(eliminations, Candidate{Index: index, Value: value})
+					}
+				}
+				if len(eliminations) > 0 {
+					return &Step{
+						Technique:    technique,
+						Eliminations: eliminations,
+						Reasons:      cells,
+						Regions:      []*Region{r, other},
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findNakedSubset finds n cells of a region whose candidates are the same n values,
+// so those values can be removed from the rest of the region.
+func findNakedSubset(c *Candidates, n int) *Step {
+	technique := [...]Technique{2: NakedPair, 3: NakedTriple, 4: NakedQuad}[n]
+	for _, r := range c.regions {
+		var cells []int
+		empty := 0
+		for _, index := range r.Indexes {
+			if count := c.candidates[index].count(); count > 0 {
+				empty++
+				if count <= n {
+					cells = append(cells, index)
+				}
+			}
+		}
+		if empty <= n {
+			continue
+		}
+		var step *Step
+		combinations(len(cells), n, func(chosen []int) bool {
+			var values bitset
+			subset := make([]int, n)
+			for i, k := range chosen {
+				subset[i] = cells[k]
+				values |= c.candidates[cells[k]]
+			}
+			if values.count() != n {
+				return false
+			}
+			var eliminations []Candidate
+			for _, index := range r.Indexes {
+				if contains(subset, index) {
+					continue
+				}
+				for _, value := range bitsetValues(c.candidates[index] & values) {
+					eliminations = append(eliminations, Candidate{Index: index, Value: value})
+				}
+			}
+			if len(eliminations) == 0 {
+				return false
+			}
+			step = &Step{Technique: technique, Eliminations: eliminations, Reasons: subset, Regions: []*Region{r}}
+			return true
+		})
+		if step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// findHiddenSubset finds n values that can only go in the same n cells of a region that must contain every value,
+// so any other candidates can be removed from those cells.
+func findHiddenSubset(c *Candidates, n int) *Step {
+	technique := [...]Technique{2: HiddenPair, 3: HiddenTriple, 4: HiddenQuad}[n]
+	for _, r := range c.fullRegions() {
+		// positions holds the positions within the region of the cells that can hold each value.
+		var values []int
+		var positions []bitset
+		unplaced := 0
+		for value := 1; value <= c.Size(); value++ {
+			var p bitset
+			for i, index := range r.Indexes {
+				if c.candidates[index].has(value) {
+					p = p.with(i + 1)
+				}
+			}
+			if p == 0 {
+				continue
+			}
+			unplaced++
+			if p.count() <= n {
+				values = append(values, value)
+				positions = append(positions, p)
+			}
+		}
+		if unplaced <= n {
+			continue
+		}
+		var step *Step
+		combinations(len(values), n, func(chosen []int) bool {
+			var cells, subset bitset
+			for _, k := range chosen {
+				cells |= positions[k]
+				subset = subset.with(values[k])
+			}
+			if cells.count() != n {
+				return false
+			}
+			var eliminations []Candidate
+			var reasons []int
+			for _, i := range bitsetValues(cells) {
+				index := r.Indexes[i-1]
+				reasons = append(reasons, index)
+				for _, value := range bitsetValues(c.candidates[index] &^ subset) {
+					eliminations = append(eliminations, Candidate{Index: index, Value: value})
+				}
+			}
+			if len(eliminations) == 0 {
+				return false
+			}
+			step = &Step{Technique: technique, Eliminations: eliminations, Reasons: reasons, Regions: []*Region{r}}
+			return true
+		})
+		if step != nil {
+			return step
+		}
+	}
+	return nil
+}
+
+// findFish finds a value whose only cells in n rows are all in the same n columns, so the value can be removed
+// from the rest of those columns, or the same with rows and columns swapped.
+// The position of a cell in its row is used as its column, so fish are only found in a single square grid.
+func findFish(c *Candidates, n int) *Step {
+	if !c.square {
+		return nil
+	}
+	technique := [...]Technique{2: XWing, 3: Swordfish, 4: Jellyfish}[n]
+	orientations := [][2][]*Region{{c.layout.rows, c.layout.columns}, {c.layout.columns, c.layout.rows}}
+	for value := 1; value <= c.Size(); value++ {
+		for _, o := range orientations {
+			bases, covers := o[0], o[1]
+			// positions holds the positions within each base of the cells that can hold the value.
+			var lines []int
+			var positions []bitset
+			for i, base := range bases {
+				var p bitset
+				for k, index := range base.Indexes {
+					if c.candidates[index].has(value) {
+						p = p.with(k + 1)
+					}
+				}
+				if count := p.count(); count >= 2 && count <= n {
+					lines = append(lines, i)
+					positions = append(positions, p)
+				}
+			}
+			var step *Step
+			combinations(len(lines), n, func(chosen []int) bool {
+				var cover bitset
+				var baseLines bitset
+				for _, k := range chosen {
+					cover |= positions[k]
+					baseLines = baseLines.with(lines[k] + 1)
+				}
+				if cover.count() != n {
+					return false
+				}
+				var eliminations []Candidate
+				var regions []*Region
+				for _, i := range bitsetValues(cover) {
+					for k, index := range covers[i-1].Indexes {
+						if !baseLines.has(k+1) && c.candidates[index].has(value) {
+							eliminations = append(eliminations, Candidate{Index: index, Value: value})
+						}
+					}
+				}
+				if len(eliminations) == 0 {
+					return false
+				}
+				var reasons []int
+				for _, k := range chosen {
+					regions = append(regions, bases[lines[k]])
+					reasons = append(reasons, c.cellsWith(bases[lines[k]], value)...)
+				}
+				for _, i := range bitsetValues(cover) {
+					regions = append(regions, covers[i-1])
+				}
+				step = &Step{Technique: technique, Eliminations: eliminations, Reasons: reasons, Regions: regions}
+				return true
+			})
+			if step != nil {
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// findXYWing finds a pivot cell with candidates x and y that sees a wing with candidates x and z,
+// and another wing with candidates y and z. Whichever value the pivot holds, one of the wings must hold z,
+// so z can be removed from every cell that sees both wings.
+func findXYWing(c *Candidates) *Step {
+	for pivot, candidates := range c.candidates {
+		if candidates.count() != 2 {
+			continue
+		}
+		var wings []int
+		for _, peer := range c.peers(pivot) {
+			shared := c.candidates[peer] & candidates
+			if c.candidates[peer].count() == 2 && shared.count() == 1 {
+				wings = append(wings, peer)
+			}
+		}
+		for i, a := range wings {
+			for _, b := range wings[i+1:] {
+				z := c.candidates[a] &^ candidates
+				if c.candidates[a]&candidates == c.candidates[b]&candidates || c.candidates[b]&^candidates != z {
+					continue
+				}
+				if s := wingStep(c, XYWing, z.lowest(), []int{pivot, a, b}, []int{a, b}); s != nil {
+					return s
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findXYZWing finds a pivot cell with candidates x, y and z that sees a wing with candidates x and z,
+// and another wing with candidates y and z. One of the three cells must hold z,
+// so z can be removed from every cell that sees all three.
+func findXYZWing(c *Candidates) *Step {
+	for pivot, candidates := range c.candidates {
+		if candidates.count() != 3 {
+			continue
+		}
+		var wings []int
+		for _, peer := range c.peers(pivot) {
+			if c.candidates[peer].count() == 2 && c.candidates[peer]&^candidates == 0 {
+				wings = append(wings, peer)
+			}
+		}
+		for i, a := range wings {
+			for _, b := range wings[i+1:] {
+				if c.candidates[a] == c.candidates[b] {
+					continue
+				}
+				z := c.candidates[a] & c.candidates[b]
+				if s := wingStep(c, XYZWing, z.lowest(), []int{pivot, a, b}, []int{pivot, a, b}); s != nil {
+					return s
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// wingStep returns a step removing value from every cell that sees all of the seen cells,
+// or nil if there is nothing to remove.
+func wingStep(c *Candidates, technique Technique, value int, reasons []int, seen []int) *Step {
+	var eliminations []Candidate
+	for _, index := range c.peers(seen[0]) {
+		if !c.candidates[index].has(value) || contains(reasons, index) {
+			continue
+		}
+		sees := true
+		for _, other := range seen[1:] {
+			sees = sees && c.sees(index, other)
+		}
+		if sees {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	return &Step{Technique: technique, Eliminations: eliminations, Reasons: reasons}
+}
+
+// findUniqueRectangle finds four cells in two rows, two columns and two sections where three of the cells
+// can only hold the same two values and the fourth can hold them along with others.
+// If the fourth cell held either value the two values could be swapped, so the puzzle would not be unique.
+// The corners are found from their rows and columns, so unique rectangles are only found in a single square grid.
+func findUniqueRectangle(c *Candidates) *Step {
+	if !c.uniqueRectangles {
+		return nil
+	}
+	size := c.Size()
+	for r1 := 0; r1 < size; r1++ {
+		for r2 := r1 + 1; r2 < size; r2++ {
+			for c1 := 0; c1 < size; c1++ {
+				for c2 := c1 + 1; c2 < size; c2++ {
+					corners := []int{r1*size + c1, r1*size + c2, r2*size + c1, r2*size + c2}
+					if s := uniqueRectangleStep(c, corners); s != nil {
+						return s
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueRectangleStep returns the step removing the shared values from the fourth of the given corners,
+// or nil if the corners are not a unique rectangle.
+func uniqueRectangleStep(c *Candidates, corners []int) *Step {
+	var pair bitset
+	for _, index := range corners {
+		if c.candidates[index].count() == 2 {
+			pair = c.candidates[index]
+			break
+		}
+	}
+	if pair == 0 {
+		return nil
+	}
+	fourth := -1
+	var reasons []int
+	for _, index := range corners {
+		switch {
+		case c.candidates[index] == pair:
+			reasons = append(reasons, index)
+		case c.candidates[index]&pair == pair && fourth == -1:
+			fourth = index
+		default:
+			return nil
+		}
+	}
+	if fourth == -1 || !c.swappable(corners) {
+		return nil
+	}
+	var eliminations []Candidate
+	for _, value := range bitsetValues(pair) {
+		eliminations = append(eliminations, Candidate{Index: fourth, Value: value})
+	}
+	return &Step{Technique: UniqueRectangle, Eliminations: eliminations, Reasons: reasons}
+}
+
+// swappable returns true if the values of the corners of a rectangle could be swapped diagonally without
+// changing the values in any section, which is when each section covering a corner covers two corners
+// in the same row or column, or all four.
+func (c *Candidates) swappable(corners []int) bool {
+	sections := make(map[int][]int)
+	for _, index := range corners {
+		if section := c.layout.positions[index].Section; section >= 0 {
+			sections[section] = append(sections[section], index)
+		}
+	}
+	for _, cells := range sections {
+		switch len(cells) {
+		case 4:
+		case 2:
+			a, b := c.layout.positions[cells[0]], c.layout.positions[cells[1]]
+			if a.Row != b.Row && a.Column != b.Column {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// findSimpleColouring finds a value where following the cells linked by being the only two cells of a region
+// that can hold the value gives a contradiction.
+func findSimpleColouring(c *Candidates) *Step {
+	for value := 1; value <= c.Size(); value++ {
+		links := make(map[int][]int)
+		for _, r := range c.fullRegions() {
+			if cells := c.cellsWith(r, value); len(cells) == 2 {
+				links[cells[0]] = append(links[cells[0]], cells[1])
+				links[cells[1]] = append(links[cells[1]], cells[0])
+			}
+		}
+		colours := make(map[int]int)
+		for start := range c.candidates {
+			if _, ok := colours[start]; ok || len(links[start]) == 0 {
+				continue
+			}
+			chain := colourChain(links, colours, start)
+			if s := colouringStep(c, value, chain, colours); s != nil {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// colourChain gives every cell linked to start one of two colours, 0 or 1, alternating along each link.
+// It returns the cells of the chain in the order they were coloured.
+func colourChain(links map[int][]int, colours map[int]int, start int) []int {
+	colours[start] = 0
+	chain := []int{start}
+	for i := 0; i < len(chain); i++ {
+		for _, next := range links[chain[i]] {
+			if _, ok := colours[next]; !ok {
+				colours[next] = 1 - colours[chain[i]]
+				chain = append(chain, next)
+			}
+		}
+	}
+	return chain
+}
+
+// colouringStep returns the step removing value using the given coloured chain, or nil if there is nothing to remove.
+// If two cells of the same colour see each other that colour cannot hold the value, so it is removed from all of them.
+// Otherwise one colour must hold the value, so it is removed from any other cell that sees both colours.
+func colouringStep(c *Candidates, value int, chain []int, colours map[int]int) *Step {
+	for i, a := range chain {
+		for _, b := range chain[i+1:] {
+			if colours[a] != colours[b] || !c.sees(a, b) {
+				continue
+			}
+			var eliminations []Candidate
+			for _, index := range chain {
+				if colours[index] == colours[a] {
+					eliminations = append(eliminations, Candidate{Index: index, Value: value})
+				}
+			}
+			return &Step{Technique: SimpleColouring, Eliminations: eliminations, Reasons: chain}
+		}
+	}
+	var eliminations []Candidate
+	for index, candidates := range c.candidates {
+		if !candidates.has(value) || contains(chain, index) {
+			continue
+		}
+		var seen [2]bool
+		for _, other := range chain {
+			if c.sees(index, other) {
+				seen[colours[other]] = true
+			}
+		}
+		if seen[0] && seen[1] {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	if len(eliminations) == 0 {
+		return nil
+	}
+	return &Step{Technique: SimpleColouring, Eliminations: eliminations, Reasons: chain}
+}
+
+// combinations calls fn with every combination of k of the numbers 0 to n-1 in ascending order,
+// stopping early if fn returns true. The slice passed to fn is reused between calls.
+func combinations(n int, k int, fn func(chosen []int) bool) {
+	chosen := make([]int, k)
+	var choose func(from int, i int) bool
+	choose = func(from int, i int) bool {
+		if i == k {
+			return fn(chosen)
+		}
+		for next := from; next <= n-(k-i); next++ {
+			chosen[i] = next
+			if choose(next+1, i+1) {
+				return true
+			}
+		}
+		return false
+	}
+	choose(0, 0)
+}
+
+// bitsetValues returns the values in the given bitset in ascending order.
+func bitsetValues(b bitset) []int {
+	var res []int
+	for b != 0 {
+		value := b.lowest()
+		res = append(res, value)
+		b = b.without(value)
+	}
+	return res
+}
+
+// contains returns true if indexes contains index.
+func contains(indexes []int, index int) bool {
+	for _, i := range indexes {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAll returns true if indexes contains every one of the given indexes.
+func containsAll(indexes []int, all []int) bool {
+	for _, index := range all {
+		if !contains(indexes, index) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSection returns true if the given region is one of the sections of the puzzle.
+func (c *Candidates) isSection(r *Region) bool {
+	for _, s := range c.layout.sections {
+		if s == r {
+			return true
+		}
+	}
+	return false
+}
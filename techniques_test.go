@@ -0,0 +1,654 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+// techniquePuzzles contains a different puzzle for each technique that can be solved logically,
+// where the technique is the hardest one needed when techniques are tried from easiest to hardest.
+var techniquePuzzles = []struct {
+	Technique Technique
+	In        []int
+}{
+	{Technique: PointingPair, In: []int{
+		0, 5, 0, 6, 0, 0, 0, 4, 1,
+		0, 0, 0, 1, 0, 0, 0, 0, 0,
+		0, 0, 1, 0, 5, 0, 0, 0, 6,
+		5, 4, 0, 0, 1, 0, 0, 0, 0,
+		0, 0, 0, 0, 9, 0, 6, 0, 0,
+		9, 2, 0, 5, 8, 4, 0, 0, 0,
+		2, 0, 7, 0, 0, 0, 0, 0, 5,
+		0, 0, 0, 0, 0, 0, 8, 9, 2,
+		0, 0, 0, 0, 0, 8, 0, 0, 0,
+	}},
+	{Technique: BoxLineReduction, In: []int{
+		0, 0, 9, 4, 0, 0, 6, 3, 0,
+		1, 0, 4, 0, 6, 9, 8, 0, 0,
+		8, 0, 6, 0, 0, 0, 4, 0, 0,
+		0, 1, 0, 0, 0, 6, 0, 0, 7,
+		0, 0, 3, 0, 8, 0, 0, 5, 0,
+		0, 0, 0, 0, 0, 7, 2, 0, 0,
+		9, 0, 0, 8, 0, 0, 0, 0, 4,
+		0, 0, 0, 0, 0, 0, 7, 9, 0,
+		0, 0, 0, 0, 9, 0, 3, 2, 0,
+	}},
+	{Technique: NakedPair, In: []int{
+		0, 0, 5, 0, 0, 0, 0, 0, 0,
+		0, 0, 9, 0, 4, 6, 0, 0, 3,
+		0, 0, 0, 0, 0, 7, 8, 0, 0,
+		0, 0, 0, 4, 0, 0, 0, 2, 0,
+		0, 5, 6, 0, 0, 0, 7, 0, 0,
+		0, 7, 0, 0, 0, 2, 0, 0, 0,
+		0, 0, 0, 0, 6, 0, 0, 0, 5,
+		0, 1, 0, 0, 0, 0, 9, 0, 2,
+		0, 9, 8, 0, 0, 4, 0, 0, 1,
+	}},
+	{Technique: XWing, In: []int{
+		5, 8, 0, 2, 0, 0, 0, 0, 1,
+		3, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 7, 0, 0, 0, 8, 0, 2,
+		4, 0, 0, 7, 0, 0, 9, 0, 0,
+		0, 0, 9, 0, 0, 8, 0, 2, 0,
+		2, 0, 5, 0, 0, 0, 1, 0, 3,
+		6, 0, 0, 0, 9, 0, 0, 0, 0,
+		0, 0, 0, 0, 4, 2, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 6, 8,
+	}},
+	{Technique: HiddenPair, In: []int{
+		0, 8, 0, 6, 0, 0, 4, 1, 0,
+		0, 0, 0, 0, 0, 0, 7, 0, 5,
+		0, 0, 0, 0, 8, 9, 0, 0, 3,
+		0, 5, 8, 0, 3, 0, 9, 0, 0,
+		6, 0, 0, 0, 0, 0, 0, 7, 0,
+		0, 3, 2, 0, 0, 0, 0, 0, 8,
+		0, 0, 5, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 1, 0, 6, 0, 0, 0,
+		3, 6, 7, 0, 2, 5, 0, 0, 0,
+	}},
+	{Technique: NakedTriple, In: []int{
+		9, 0, 0, 0, 0, 8, 7, 0, 0,
+		0, 0, 1, 7, 0, 0, 2, 6, 0,
+		0, 0, 8, 0, 1, 0, 9, 0, 0,
+		4, 0, 5, 3, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 2, 0,
+		0, 8, 0, 0, 0, 0, 4, 0, 7,
+		0, 3, 0, 6, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 9, 5, 0, 3, 1,
+		0, 0, 0, 0, 4, 0, 0, 0, 0,
+	}},
+	{Technique: Swordfish, In: []int{
+		0, 0, 8, 0, 0, 9, 0, 6, 0,
+		5, 9, 0, 6, 0, 0, 3, 0, 8,
+		0, 0, 0, 1, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 4, 0, 7, 0,
+		7, 0, 4, 0, 0, 0, 6, 8, 0,
+		0, 5, 0, 0, 0, 0, 1, 0, 3,
+		6, 0, 0, 0, 0, 1, 0, 0, 0,
+		0, 0, 0, 9, 0, 5, 0, 0, 1,
+		0, 7, 0, 3, 0, 0, 0, 0, 0,
+	}},
+	{Technique: HiddenTriple, In: []int{
+		0, 0, 0, 0, 4, 0, 6, 0, 0,
+		9, 0, 6, 0, 0, 2, 4, 0, 0,
+		4, 0, 0, 0, 0, 1, 0, 8, 0,
+		0, 0, 0, 5, 0, 0, 0, 7, 0,
+		1, 0, 8, 0, 2, 0, 0, 0, 0,
+		3, 5, 0, 0, 0, 0, 8, 9, 0,
+		0, 1, 9, 0, 5, 0, 0, 0, 0,
+		0, 0, 0, 6, 0, 0, 7, 4, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}},
+	{Technique: XYWing, In: []int{
+		5, 0, 8, 0, 2, 0, 0, 0, 0,
+		6, 7, 0, 0, 0, 5, 4, 0, 0,
+		0, 3, 0, 9, 0, 0, 0, 0, 0,
+		9, 0, 0, 7, 0, 0, 0, 3, 0,
+		0, 0, 0, 0, 1, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 4,
+		0, 0, 7, 4, 0, 0, 0, 0, 8,
+		0, 0, 1, 0, 0, 0, 5, 7, 0,
+		0, 0, 2, 0, 6, 0, 0, 0, 9,
+	}},
+	{Technique: XYZWing, In: []int{
+		0, 6, 0, 0, 0, 0, 0, 5, 9,
+		0, 7, 8, 0, 0, 0, 1, 0, 0,
+		5, 0, 0, 2, 0, 6, 0, 0, 7,
+		2, 0, 7, 0, 0, 0, 0, 0, 0,
+		0, 0, 9, 0, 0, 0, 0, 0, 8,
+		3, 0, 0, 7, 0, 2, 6, 0, 0,
+		0, 0, 0, 1, 0, 0, 0, 0, 0,
+		8, 0, 1, 0, 4, 0, 0, 0, 0,
+		0, 5, 0, 9, 0, 0, 0, 0, 2,
+	}},
+	{Technique: UniqueRectangle, In: []int{
+		0, 0, 8, 0, 0, 3, 2, 0, 0,
+		9, 0, 0, 0, 0, 5, 4, 0, 0,
+		0, 0, 0, 0, 6, 0, 0, 0, 0,
+		0, 7, 0, 1, 0, 0, 0, 3, 9,
+		0, 0, 0, 0, 0, 4, 8, 0, 0,
+		5, 3, 6, 0, 9, 0, 0, 0, 0,
+		6, 0, 0, 4, 0, 0, 0, 0, 8,
+		0, 2, 0, 0, 0, 1, 0, 4, 0,
+		0, 0, 0, 0, 0, 0, 5, 0, 0,
+	}},
+	{Technique: SimpleColouring, In: []int{
+		3, 8, 0, 0, 9, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 1, 3, 0, 5,
+		0, 0, 2, 4, 0, 0, 0, 0, 1,
+		8, 2, 0, 1, 0, 0, 0, 6, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 4,
+		0, 0, 0, 0, 0, 4, 9, 0, 2,
+		9, 0, 0, 0, 6, 0, 0, 5, 0,
+		0, 0, 7, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 5, 0, 1, 0,
+	}},
+	{Technique: NakedQuad, In: []int{
+		0, 0, 3, 0, 0, 0, 0, 0, 8,
+		0, 0, 1, 0, 6, 9, 0, 0, 0,
+		0, 0, 8, 7, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 4, 0, 6, 7, 0,
+		0, 0, 9, 0, 0, 0, 0, 0, 3,
+		7, 0, 0, 0, 8, 0, 5, 0, 0,
+		8, 6, 0, 1, 0, 0, 0, 0, 2,
+		0, 0, 0, 3, 0, 0, 0, 9, 6,
+		0, 0, 7, 0, 0, 0, 0, 4, 0,
+	}},
+}
+
+func TestCandidates_Solve_Techniques(t *testing.T) {
+	for _, tc := range techniquePuzzles {
+		t.Run(tc.Technique.String(), func(t *testing.T) {
+			exp := solve(t, tc.In)
+			p, err := NewPuzzle(tc.In)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			c := p.Candidates()
+			steps, err := c.Solve()
+			if err != nil {
+				t.Errorf("could not solve puzzle logically: %s", err)
+				return
+			}
+			hardest := HiddenSingle
+			for _, s := range steps {
+				if s.Technique > hardest {
+					hardest = s.Technique
+				}
+				checkStep(t, s, exp)
+			}
+			if hardest != tc.Technique {
+				t.Errorf("expected %s to be the hardest technique, got %s", tc.Technique, hardest)
+			}
+			if got := c.Items(); !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected %v, got %v", exp, got)
+			}
+		})
+	}
+}
+
+// solve returns the solution of the given puzzle found by the search.
+func solve(t *testing.T, in []int, opts ...Option) []int {
+	p, err := NewPuzzle(in, opts...)
+	if err != nil {
+		t.Fatalf("could not create new puzzle: %s", err)
+	}
+	if err := p.Solve(); err != nil {
+		t.Fatalf("could not solve puzzle: %s", err)
+	}
+	res, err := p.Result()
+	if err != nil {
+		t.Fatalf("could not get result: %s", err)
+	}
+	return res
+}
+
+// checkStep checks the placements and eliminations of the given step agree with the solution.
+func checkStep(t *testing.T, s *Step, solution []int) {
+	t.Helper()
+	for _, p := range s.Placements {
+		if solution[p.Index] != p.Value {
+			t.Errorf("%v places %v but the solution has %d", s, p, solution[p.Index])
+		}
+	}
+	for _, e := range s.Eliminations {
+		if solution[e.Index] == e.Value {
+			t.Errorf("%v removes %v which is in the solution", s, e)
+		}
+	}
+}
+
+// emptyCandidates returns the candidates of an empty 9x9 puzzle with the given values removed from each cell.
+func emptyCandidates(t *testing.T, removed map[int][]int) *Candidates {
+	p, err := NewPuzzle(make([]int, 81))
+	if err != nil {
+		t.Fatalf("could not create new puzzle: %s", err)
+	}
+	c := p.Candidates()
+	for index, values := range removed {
+		for _, value := range values {
+			c.candidates[index] = c.candidates[index].without(value)
+		}
+	}
+	return c
+}
+
+func TestCandidates_Next_Jellyfish(t *testing.T) {
+	// 1 can only go in columns 0, 2, 4 and 6 of rows 0, 2, 4 and 6.
+	removed := make(map[int][]int)
+	for _, row := range []int{0, 2, 4, 6} {
+		for _, column := range []int{1, 3, 5, 7, 8} {
+			removed[row*9+column] = []int{1}
+		}
+	}
+	c := emptyCandidates(t, removed)
+	got, err := c.Next()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if got.Technique != Jellyfish {
+		t.Errorf("expected %s, got %v", Jellyfish, got)
+		return
+	}
+	var exp []Candidate
+	for _, column := range []int{0, 2, 4, 6} {
+		for _, row := range []int{1, 3, 5, 7, 8} {
+			exp = append(exp, Candidate{Index: row*9 + column, Value: 1})
+		}
+	}
+	if !reflect.DeepEqual(exp, got.Eliminations) {
+		t.Errorf("expected eliminations %v, got %v", exp, got.Eliminations)
+	}
+	if len(got.Reasons) != 16 || len(got.Regions) != 8 {
+		t.Errorf("expected 16 reasons and 8 regions, got %v and %v", got.Reasons, got.Regions)
+	}
+}
+
+func TestCandidates_Next_HiddenQuad(t *testing.T) {
+	// 1, 2, 3 and 4 can only go in the first four cells of the first row.
+	removed := make(map[int][]int)
+	for index := 4; index < 9; index++ {
+		removed[index] = []int{1, 2, 3, 4}
+	}
+	c := emptyCandidates(t, removed)
+	got, err := c.Next()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	var eliminations []Candidate
+	for index := 0; index < 4; index++ {
+		for value := 5; value <= 9; value++ {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	exp := &Step{
+		Technique:    HiddenQuad,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 1, 2, 3},
+		Regions:      []*Region{c.layout.rows[0]},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestCandidates_Next_NakedPair(t *testing.T) {
+	// the first two cells can only hold 1 or 2.
+	c := emptyCandidates(t, map[int][]int{
+		0: {3, 4, 5, 6, 7, 8, 9},
+		1: {3, 4, 5, 6, 7, 8, 9},
+	})
+	got, err := c.Next()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	var eliminations []Candidate
+	for index := 2; index < 9; index++ {
+		eliminations = append(eliminations, Candidate{Index: index, Value: 1}, Candidate{Index: index, Value: 2})
+	}
+	exp := &Step{
+		Technique:    NakedPair,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 1},
+		Regions:      []*Region{c.layout.rows[0]},
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+// except returns every value from 1 to 9 apart from the given values,
+// which are the candidates to remove to leave a cell with only the given values.
+func except(values ...int) []int {
+	var res []int
+	for value := 1; value <= 9; value++ {
+		if !contains(values, value) {
+			res = append(res, value)
+		}
+	}
+	return res
+}
+
+// removeValue adds value to the candidates to remove from each of the given cells.
+func removeValue(removed map[int][]int, value int, indexes ...int) {
+	for _, index := range indexes {
+		removed[index] = append(removed[index], value)
+	}
+}
+
+// checkNext checks the next step of the given candidates is the expected step.
+func checkNext(t *testing.T, c *Candidates, exp *Step) {
+	t.Helper()
+	got, err := c.Next()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestCandidates_Next_PointingPair(t *testing.T) {
+	// 1 can only go in the first two cells of the first section, which are both in the first row.
+	removed := make(map[int][]int)
+	removeValue(removed, 1, 2, 9, 10, 11, 18, 19, 20)
+	c := emptyCandidates(t, removed)
+	var eliminations []Candidate
+	for index := 3; index < 9; index++ {
+		eliminations = append(eliminations, Candidate{Index: index, Value: 1})
+	}
+	checkNext(t, c, &Step{
+		Technique:    PointingPair,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 1},
+		Regions:      []*Region{c.layout.sections[0], c.layout.rows[0]},
+	})
+}
+
+func TestCandidates_Next_BoxLineReduction(t *testing.T) {
+	// 1 can only go in the first two cells of the first row, which are both in the first section.
+	removed := make(map[int][]int)
+	removeValue(removed, 1, 2, 3, 4, 5, 6, 7, 8)
+	c := emptyCandidates(t, removed)
+	var eliminations []Candidate
+	for _, index := range []int{9, 10, 11, 18, 19, 20} {
+		eliminations = append(eliminations, Candidate{Index: index, Value: 1})
+	}
+	checkNext(t, c, &Step{
+		Technique:    BoxLineReduction,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 1},
+		Regions:      []*Region{c.layout.rows[0], c.layout.sections[0]},
+	})
+}
+
+func TestCandidates_Next_XWing(t *testing.T) {
+	// 1 can only go in the first and fifth columns of the first and fifth rows.
+	removed := make(map[int][]int)
+	removeValue(removed, 1, 1, 2, 3, 5, 6, 7, 8, 37, 38, 39, 41, 42, 43, 44)
+	c := emptyCandidates(t, removed)
+	var eliminations []Candidate
+	for _, column := range []int{0, 4} {
+		for _, row := range []int{1, 2, 3, 5, 6, 7, 8} {
+			eliminations = append(eliminations, Candidate{Index: row*9 + column, Value: 1})
+		}
+	}
+	checkNext(t, c, &Step{
+		Technique:    XWing,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 4, 36, 40},
+		Regions:      []*Region{c.layout.rows[0], c.layout.rows[4], c.layout.columns[0], c.layout.columns[4]},
+	})
+}
+
+func TestCandidates_Next_HiddenPair(t *testing.T) {
+	// 1 and 2 can only go in the first and fifth cells of the first row.
+	removed := make(map[int][]int)
+	for _, index := range []int{1, 2, 3, 5, 6, 7, 8} {
+		removed[index] = []int{1, 2}
+	}
+	c := emptyCandidates(t, removed)
+	var eliminations []Candidate
+	for _, index := range []int{0, 4} {
+		for value := 3; value <= 9; value++ {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	checkNext(t, c, &Step{
+		Technique:    HiddenPair,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 4},
+		Regions:      []*Region{c.layout.rows[0]},
+	})
+}
+
+func TestCandidates_Next_NakedTriple(t *testing.T) {
+	// the first, fifth and last cells of the first row can only hold 1, 2 or 3 between them.
+	c := emptyCandidates(t, map[int][]int{
+		0: except(1, 2),
+		4: except(2, 3),
+		8: except(1, 3),
+	})
+	var eliminations []Candidate
+	for _, index := range []int{1, 2, 3, 5, 6, 7} {
+		for value := 1; value <= 3; value++ {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	checkNext(t, c, &Step{
+		Technique:    NakedTriple,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 4, 8},
+		Regions:      []*Region{c.layout.rows[0]},
+	})
+}
+
+func TestCandidates_Next_Swordfish(t *testing.T) {
+	// 1 can only go in the first, fourth and seventh columns of the first, fourth and seventh rows,
+	// with two cells in each row.
+	removed := make(map[int][]int)
+	removeValue(removed, 1, 1, 2, 4, 5, 6, 7, 8)
+	removeValue(removed, 1, 27, 28, 29, 31, 32, 34, 35)
+	removeValue(removed, 1, 55, 56, 57, 58, 59, 61, 62)
+	c := emptyCandidates(t, removed)
+	var eliminations []Candidate
+	for _, column := range []int{0, 3, 6} {
+		for _, row := range []int{1, 2, 4, 5, 7, 8} {
+			eliminations = append(eliminations, Candidate{Index: row*9 + column, Value: 1})
+		}
+	}
+	checkNext(t, c, &Step{
+		Technique:    Swordfish,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 3, 30, 33, 54, 60},
+		Regions: []*Region{
+			c.layout.rows[0], c.layout.rows[3], c.layout.rows[6],
+			c.layout.columns[0], c.layout.columns[3], c.layout.columns[6],
+		},
+	})
+}
+
+func TestCandidates_Next_HiddenTriple(t *testing.T) {
+	// 1, 2 and 3 can only go in the first, fifth and last cells of the first row.
+	removed := make(map[int][]int)
+	for _, index := range []int{1, 2, 3, 5, 6, 7} {
+		removed[index] = []int{1, 2, 3}
+	}
+	c := emptyCandidates(t, removed)
+	var eliminations []Candidate
+	for _, index := range []int{0, 4, 8} {
+		for value := 4; value <= 9; value++ {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	checkNext(t, c, &Step{
+		Technique:    HiddenTriple,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 4, 8},
+		Regions:      []*Region{c.layout.rows[0]},
+	})
+}
+
+func TestCandidates_Next_XYWing(t *testing.T) {
+	// the pivot in the first cell can hold 1 or 2, the wing in its row 1 or 3 and the wing in its column 2 or 3,
+	// so one of the wings holds 3 and the cell seeing both can't.
+	c := emptyCandidates(t, map[int][]int{
+		0:  except(1, 2),
+		4:  except(1, 3),
+		36: except(2, 3),
+	})
+	checkNext(t, c, &Step{
+		Technique:    XYWing,
+		Eliminations: []Candidate{{Index: 40, Value: 3}},
+		Reasons:      []int{0, 4, 36},
+	})
+}
+
+func TestCandidates_Next_XYZWing(t *testing.T) {
+	// the pivot in the first cell can hold 1, 2 or 3, the wing in its row 1 or 3 and the wing in its section 2 or 3,
+	// so one of the three holds 3 and the cells seeing all of them can't.
+	c := emptyCandidates(t, map[int][]int{
+		0:  except(1, 2, 3),
+		4:  except(1, 3),
+		10: except(2, 3),
+	})
+	checkNext(t, c, &Step{
+		Technique:    XYZWing,
+		Eliminations: []Candidate{{Index: 1, Value: 3}, {Index: 2, Value: 3}},
+		Reasons:      []int{0, 4, 10},
+	})
+}
+
+func TestCandidates_Next_UniqueRectangle(t *testing.T) {
+	// three corners of a rectangle across the first two sections can only hold 1 or 2,
+	// so the fourth corner can't hold either or they could be swapped.
+	removed := map[int][]int{
+		0: except(1, 2),
+		3: except(1, 2),
+		9: except(1, 2),
+	}
+	// remove 1 and 2 from the rest of the units of the naked pairs, so only the rectangle makes progress.
+	for _, index := range []int{1, 2, 4, 5, 6, 7, 8, 10, 11, 18, 19, 20, 27, 36, 45, 54, 63, 72} {
+		removed[index] = []int{1, 2}
+	}
+	c := emptyCandidates(t, removed)
+	checkNext(t, c, &Step{
+		Technique:    UniqueRectangle,
+		Eliminations: []Candidate{{Index: 12, Value: 1}, {Index: 12, Value: 2}},
+		Reasons:      []int{0, 3, 9},
+	})
+
+	// other rules could prevent the values from being swapped, so unique rectangles are not used.
+	c.uniqueRectangles = false
+	if s, err := c.Next(); err == nil && s.Technique == UniqueRectangle {
+		t.Errorf("expected unique rectangles not to be used, got %v", s)
+	}
+}
+
+func TestCandidates_Next_SimpleColouring(t *testing.T) {
+	t.Run("SeesBothColours", func(t *testing.T) {
+		// 1 is in the first or fifth cell of the first row, the first or second row of the second section,
+		// and the second or eighth row of the sixth column. Following the chain, either the first cell
+		// or the last cell of the chain in the eighth row holds 1, and the first cell of the eighth row sees both.
+		removed := make(map[int][]int)
+		removeValue(removed, 1, 1, 2, 3, 5, 6, 7, 8)
+		removeValue(removed, 1, 12, 13, 21, 22, 23)
+		removeValue(removed, 1, 32, 41, 50, 59, 77)
+		c := emptyCandidates(t, removed)
+		checkNext(t, c, &Step{
+			Technique:    SimpleColouring,
+			Eliminations: []Candidate{{Index: 63, Value: 1}},
+			Reasons:      []int{0, 4, 14, 68},
+		})
+	})
+	t.Run("SeesItself", func(t *testing.T) {
+		// 1 is linked around the first and last rows and the third and fifth columns, which gives the first cell
+		// the same colour as the third cell of the third row in the same section, so that colour can't hold 1.
+		removed := make(map[int][]int)
+		removeValue(removed, 1, 1, 2, 3, 5, 6, 7, 8)
+		removeValue(removed, 1, 13, 22, 31, 40, 49, 58, 67)
+		removeValue(removed, 1, 72, 73, 75, 77, 78, 79, 80)
+		removeValue(removed, 1, 11, 29, 38, 47, 56, 65)
+		c := emptyCandidates(t, removed)
+		checkNext(t, c, &Step{
+			Technique:    SimpleColouring,
+			Eliminations: []Candidate{{Index: 0, Value: 1}, {Index: 76, Value: 1}, {Index: 20, Value: 1}},
+			Reasons:      []int{0, 4, 76, 74, 20},
+		})
+	})
+}
+
+func TestCandidates_Next_NakedQuad(t *testing.T) {
+	// four cells of the first row can only hold 1, 2, 3 or 4 between them.
+	c := emptyCandidates(t, map[int][]int{
+		0: except(1, 2),
+		3: except(2, 3),
+		6: except(3, 4),
+		8: except(1, 4),
+	})
+	var eliminations []Candidate
+	for _, index := range []int{1, 2, 4, 5, 7} {
+		for value := 1; value <= 4; value++ {
+			eliminations = append(eliminations, Candidate{Index: index, Value: value})
+		}
+	}
+	checkNext(t, c, &Step{
+		Technique:    NakedQuad,
+		Eliminations: eliminations,
+		Reasons:      []int{0, 3, 6, 8},
+		Regions:      []*Region{c.layout.rows[0]},
+	})
+}
+
+func TestCandidates_Next_MultiGrid(t *testing.T) {
+	// the rows and columns of overlapping grids don't line up by position, so fish and unique rectangles can't be used.
+	p, err := NewSamuraiPuzzle(samuraiPuzzle)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	c := p.puzzle.Candidates()
+	if c.square || c.uniqueRectangles {
+		t.Errorf("expected a multi-grid puzzle not to be square")
+	}
+	for n := 2; n <= 4; n++ {
+		if s := findFish(c, n); s != nil {
+			t.Errorf("expected no fish, got %v", s)
+		}
+	}
+	if s := findUniqueRectangle(c); s != nil {
+		t.Errorf("expected no unique rectangle, got %v", s)
+	}
+}
+
+func TestTechnique_String(t *testing.T) {
+	tests := map[Technique]string{
+		HiddenSingle:    "hidden single",
+		XWing:           "x-wing",
+		UniqueRectangle: "unique rectangle",
+		HiddenQuad:      "hidden quad",
+		Technique(-1):   "unknown",
+	}
+	for technique, exp := range tests {
+		if got := technique.String(); got != exp {
+			t.Errorf("expected %q, got %q", exp, got)
+		}
+	}
+}
+
+func TestStep_String(t *testing.T) {
+	s := &Step{
+		Technique:    NakedPair,
+		Eliminations: []Candidate{{Index: 2, Value: 1}, {Index: 3, Value: 2}},
+		Reasons:      []int{0, 1},
+	}
+	exp := "naked pair: remove 1 from cell 2, remove 2 from cell 3 because of cells [0 1]"
+	if got := s.String(); got != exp {
+		t.Errorf("expected %q, got %q", exp, got)
+	}
+}
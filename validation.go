@@ -0,0 +1,123 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// CellPosition describes where a cell is within a puzzle.
+// All values are zero based, and Section is -1 if the cell is not in a section.
+type CellPosition struct {
+	Index   int
+	Row     int
+	Column  int
+	Section int
+}
+
+// InvalidValueError is returned when a cell contains a value outside of the range allowed by the puzzle size.
+type InvalidValueError struct {
+	CellPosition
+	Value int
+	// Max is the largest value allowed in the puzzle.
+	Max int
+}
+
+// Error returns the error message.
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid value %d at row %d, column %d: values must be between 0 and %d",
+		e.Value, e.Row, e.Column, e.Max)
+}
+
+// ConflictError is returned when two cells in the same unit are given the same value.
+type ConflictError struct {
+	Value int
+	// Unit is the kind of unit the cells share, e.g. row, column or section.
+	Unit string
+	// Cell and Other are the positions of the conflicting cells.
+	Cell  CellPosition
+	Other CellPosition
+}
+
+// Error returns the error message.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("value %d at row %d, column %d conflicts with row %d, column %d in the same %s",
+		e.Value, e.Cell.Row, e.Cell.Column, e.Other.Row, e.Other.Column, e.Unit)
+}
+
+// ConstraintError is returned when a cell holds a value that breaks a constraint other than a region.
+type ConstraintError struct {
+	CellPosition
+	Value      int
+	Constraint Constraint
+}
+
+// Error returns the error message.
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("value %d at row %d, column %d breaks constraint %v", e.Value, e.Row, e.Column, e.Constraint)
+}
+
+// position returns the position of the cell at the given index.
+func (g *grid) position(index int) CellPosition {
+	return g.layout.positions[index]
+}
+
+// validateValues returns an *InvalidValueError if any of the given items are outside of the range 0 to puzzleSize.
+func validateValues(items []int, l *layout) error {
+	for index, value := range items {
+		if value < 0 || value > l.puzzleSize {
+			return &InvalidValueError{
+				CellPosition: l.positions[index],
+				Value:        value,
+				Max:          l.puzzleSize,
+			}
+		}
+	}
+	return nil
+}
+
+// validate returns a *ConflictError if any region in the grid contains the same value more than once,
+// or a *ConstraintError if a value breaks any other constraint.
+func (g *grid) validate() error {
+	for _, r := range g.regions {
+		seen := make(map[int]int, len(r.Indexes))
+		for _, cellIndex := range r.Indexes {
+			value := g.cells[cellIndex].value
+			if value == 0 {
+				continue
+			}
+			if other, ok := seen[value]; ok {
+				return &ConflictError{
+					Value: value,
+					Unit:  r.Kind,
+					Cell:  g.position(cellIndex),
+					Other: g.position(other),
+				}
+			}
+			seen[value] = cellIndex
+		}
+	}
+	for _, a := range g.allowedValues {
+		for _, cellIndex := range a.Indexes {
+			value := g.cells[cellIndex].value
+			if value != 0 && !a.Allowed(g, cellIndex, value) {
+				return &ConstraintError{
+					CellPosition: g.position(cellIndex),
+					Value:        value,
+					Constraint:   a,
+				}
+			}
+		}
+	}
+	for _, c := range g.constraints {
+		for _, cellIndex := range c.Cells() {
+			value := g.cells[cellIndex].value
+			if value != 0 && !c.Allowed(g, cellIndex, value) {
+				return &ConstraintError{
+					CellPosition: g.position(cellIndex),
+					Value:        value,
+					Constraint:   c,
+				}
+			}
+		}
+	}
+	return nil
+}
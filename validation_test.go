@@ -0,0 +1,155 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewPuzzle_InvalidPuzzleSize(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Items []int
+	}{
+		{Name: "Empty", Items: []int{}},
+		{Name: "NotSquare", Items: make([]int, 80)},
+		{Name: "NoSquareSections", Items: make([]int, 4)},
+		{Name: "TooLarge", Items: make([]int, 81*81)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(tc.Items)
+			if !errors.Is(err, ErrInvalidPuzzleSize) {
+				t.Errorf("expected error %v, got %v", ErrInvalidPuzzleSize, err)
+			}
+		})
+	}
+}
+
+func TestCalculatePuzzleSize_Invalid(t *testing.T) {
+	for _, n := range []int{0, 2, 15, 80, 82} {
+		if _, err := CalculatePuzzleSize(make([]int, n)); !errors.Is(err, ErrInvalidPuzzleSize) {
+			t.Errorf("%d items: expected error %v, got %v", n, ErrInvalidPuzzleSize, err)
+		}
+	}
+}
+
+func TestNewPuzzle_InvalidValue(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Items []int
+		Exp   *InvalidValueError
+	}{
+		{
+			Name: "TooHigh",
+			Items: []int{
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 5,
+				0, 0, 0, 0,
+			},
+			Exp: &InvalidValueError{
+				CellPosition: CellPosition{Index: 11, Row: 2, Column: 3, Section: 3},
+				Value:        5,
+				Max:          4,
+			},
+		},
+		{
+			Name: "Negative",
+			Items: []int{
+				0, -1, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			Exp: &InvalidValueError{
+				CellPosition: CellPosition{Index: 1, Row: 0, Column: 1, Section: 0},
+				Value:        -1,
+				Max:          4,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(tc.Items)
+			var got *InvalidValueError
+			if !errors.As(err, &got) {
+				t.Errorf("expected *InvalidValueError, got %v", err)
+				return
+			}
+			if !reflect.DeepEqual(tc.Exp, got) {
+				t.Errorf("expected %+v, got %+v", tc.Exp, got)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_Conflict(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Items []int
+		Exp   *ConflictError
+	}{
+		{
+			Name: "Row",
+			Items: []int{
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				2, 0, 0, 2,
+				0, 0, 0, 0,
+			},
+			Exp: &ConflictError{
+				Value: 2,
+				Unit:  "row",
+				Cell:  CellPosition{Index: 11, Row: 2, Column: 3, Section: 3},
+				Other: CellPosition{Index: 8, Row: 2, Column: 0, Section: 2},
+			},
+		},
+		{
+			Name: "Column",
+			Items: []int{
+				0, 3, 0, 0,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+				0, 3, 0, 0,
+			},
+			Exp: &ConflictError{
+				Value: 3,
+				Unit:  "column",
+				Cell:  CellPosition{Index: 13, Row: 3, Column: 1, Section: 2},
+				Other: CellPosition{Index: 1, Row: 0, Column: 1, Section: 0},
+			},
+		},
+		{
+			Name: "Section",
+			Items: []int{
+				0, 0, 4, 0,
+				0, 0, 0, 4,
+				0, 0, 0, 0,
+				0, 0, 0, 0,
+			},
+			Exp: &ConflictError{
+				Value: 4,
+				Unit:  "section",
+				Cell:  CellPosition{Index: 7, Row: 1, Column: 3, Section: 1},
+				Other: CellPosition{Index: 2, Row: 0, Column: 2, Section: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(tc.Items)
+			var got *ConflictError
+			if !errors.As(err, &got) {
+				t.Errorf("expected *ConflictError, got %v", err)
+				return
+			}
+			if !reflect.DeepEqual(tc.Exp, got) {
+				t.Errorf("expected %+v, got %+v", tc.Exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package sudoku
+
+import (
+	"fmt"
+)
+
+// AllowedValues is a constraint restricting every cell it covers to one of the given values,
+// such as the shaded cells of an odd/even puzzle that can only hold even values.
+//
+// Allowed values are handled directly by the solver rather than through Allowed, so they are as cheap as regions.
+// A cell covered by more than one set of allowed values can only hold values in all of them.
+type AllowedValues struct {
+	// Kind describes the values, such as odd or even. It is used in error messages.
+	Kind    string
+	Indexes []int
+	Values  []int
+}
+
+// Cells returns the indexes of the cells that are restricted.
+func (a *AllowedValues) Cells() []int {
+	return a.Indexes
+}
+
+// Allowed returns true if the value is one of the allowed values.
+func (a *AllowedValues) Allowed(values Values, index int, value int) bool {
+	for _, v := range a.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a description of the allowed values.
+func (a *AllowedValues) String() string {
+	return fmt.Sprintf("%s values %v in %v", a.Kind, a.Values, a.Indexes)
+}
+
+// validate returns an error if there are no allowed values, or any are outside of a puzzle of the given size.
+func (a *AllowedValues) validate(puzzleSize int) error {
+	if len(a.Values) == 0 {
+		return fmt.Errorf("%w: %v has no values", ErrInvalidConstraint, a)
+	}
+	for _, v := range a.Values {
+		if v < 1 || v > puzzleSize {
+			return fmt.Errorf("%w: %v allows %d which is not between 1 and %d", ErrInvalidConstraint, a, v, puzzleSize)
+		}
+	}
+	return nil
+}
+
+// bitset returns the allowed values as a bitset.
+func (a *AllowedValues) bitset() bitset {
+	var b bitset
+	for _, v := range a.Values {
+		b = b.with(v)
+	}
+	return b
+}
+
+// OddCells returns a constraint restricting the given cells to odd values in a puzzle of the given size.
+func OddCells(puzzleSize int, indexes ...int) *AllowedValues {
+	return parityCells("odd", 1, puzzleSize, indexes)
+}
+
+// EvenCells returns a constraint restricting the given cells to even values in a puzzle of the given size.
+func EvenCells(puzzleSize int, indexes ...int) *AllowedValues {
+	return parityCells("even", 2, puzzleSize, indexes)
+}
+
+// parityCells returns a constraint restricting the given cells to every other value starting at first.
+func parityCells(kind string, first int, puzzleSize int, indexes []int) *AllowedValues {
+	a := &AllowedValues{Kind: kind, Indexes: indexes}
+	for v := first; v <= puzzleSize; v += 2 {
+		a.Values = append(a.Values, v)
+	}
+	return a
+}
@@ -0,0 +1,157 @@
+package sudoku
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOddCells_EvenCells(t *testing.T) {
+	if exp, got := (&AllowedValues{Kind: "odd", Indexes: []int{1, 2}, Values: []int{1, 3, 5, 7, 9}}), OddCells(9, 1, 2); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+	if exp, got := (&AllowedValues{Kind: "even", Indexes: []int{3}, Values: []int{2, 4}}), EvenCells(4, 3); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %v, got %v", exp, got)
+	}
+}
+
+func TestPuzzle_Solve_OddEven(t *testing.T) {
+	// the givens of TestPuzzle_Solve_Diagonals with an extra 4, which only has a unique solution
+	// once the even cells are shaded.
+	in := []int{
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		4, 0, 5, 0, 3, 7, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 2, 3, 0,
+		0, 0, 0, 6, 0, 0, 0, 1, 0,
+		2, 0, 0, 0, 0, 0, 4, 9, 0,
+		0, 0, 0, 7, 0, 0, 0, 0, 2,
+		0, 1, 0, 0, 0, 8, 7, 0, 5,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 9, 0, 0, 0, 0, 1, 0, 0,
+	}
+	even := WithEven(2, 3, 4, 5, 9, 10, 16, 17, 19, 22, 24, 26, 29, 30, 31, 33, 36, 38,
+		42, 44, 46, 49, 50, 53, 54, 57, 59, 61, 64, 66, 69, 70, 72, 74, 77, 79)
+
+	for _, config := range solveConfigs {
+		t.Run(config.Name, func(t *testing.T) {
+			p, err := NewPuzzle(in, append(config.Options, even)...)
+			if err != nil {
+				t.Errorf("could not create new puzzle: %s", err)
+				return
+			}
+			if err := p.Solve(); err != nil {
+				t.Errorf("could not solve puzzle: %s", err)
+				return
+			}
+			got, err := p.Result()
+			if err != nil {
+				t.Errorf("could not get result: %s", err)
+				return
+			}
+			if !reflect.DeepEqual(edgeSolution, got) {
+				t.Errorf("expected %v, got %v", edgeSolution, got)
+			}
+		})
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		p, err := NewPuzzle(in, even)
+		if err != nil {
+			t.Errorf("could not create new puzzle: %s", err)
+			return
+		}
+		if unique, err := p.HasUniqueSolution(); !unique {
+			t.Errorf("expected puzzle to be unique, got %v", err)
+		}
+	})
+}
+
+func TestPuzzle_Solve_AllowedValues(t *testing.T) {
+	// the first cell can only be 3 or 4, and the diagonal can only hold values of at least 2 and at most 3.
+	p, err := NewPuzzle(make([]int, 16),
+		WithAllowedValues(&AllowedValues{Kind: "corner", Indexes: []int{0}, Values: []int{3, 4}}),
+		WithAllowedValues(&AllowedValues{Kind: "diagonal", Indexes: []int{0, 5, 10, 15}, Values: []int{2, 3}}),
+	)
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	count := 0
+	for it := p.Solutions(); it.Next(); count++ {
+		got := it.Solution()
+		checkSolution(t, make([]int, 16), got)
+		if got[0] != 3 {
+			t.Errorf("expected first cell to be 3, got %d", got[0])
+		}
+		for _, index := range []int{5, 10, 15} {
+			if got[index] != 2 && got[index] != 3 {
+				t.Errorf("expected cell %d to be 2 or 3, got %d", index, got[index])
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("expected at least one solution")
+	}
+}
+
+func TestNewPuzzle_AllowedValues_Invalid(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Option Option
+	}{
+		{Name: "NoValues", Option: WithAllowedValues(&AllowedValues{Kind: "none", Indexes: []int{0}})},
+		{Name: "ValueTooLarge", Option: WithAllowedValues(&AllowedValues{Kind: "large", Indexes: []int{0}, Values: []int{5}})},
+		{Name: "CellOutside", Option: WithOdd(16)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewPuzzle(make([]int, 16), tc.Option)
+			if !errors.Is(err, ErrInvalidConstraint) {
+				t.Errorf("expected error %v, got %v", ErrInvalidConstraint, err)
+			}
+		})
+	}
+}
+
+func TestNewPuzzle_OddEven_ConstraintError(t *testing.T) {
+	_, err := NewPuzzle([]int{
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0, 0, 3, 0,
+		0, 0, 0, 0,
+	}, WithEven(10))
+	var got *ConstraintError
+	if !errors.As(err, &got) {
+		t.Errorf("expected *ConstraintError, got %v", err)
+		return
+	}
+	exp := &ConstraintError{
+		CellPosition: CellPosition{Index: 10, Row: 2, Column: 2, Section: 3},
+		Value:        3,
+		Constraint:   EvenCells(4, 10),
+	}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected %+v, got %+v", exp, got)
+	}
+}
+
+func TestPuzzle_Constraints_AllowedValues(t *testing.T) {
+	cage := &Cage{Sum: 3, Indexes: []int{2, 3}}
+	p, err := NewPuzzle(make([]int, 16), WithOdd(0, 1), WithCages(cage))
+	if err != nil {
+		t.Errorf("could not create new puzzle: %s", err)
+		return
+	}
+	got := p.Constraints()
+	if exp := 4*3 + 2; len(got) != exp {
+		t.Errorf("expected %d constraints, got %d", exp, len(got))
+		return
+	}
+	if exp := OddCells(4, 0, 1); !reflect.DeepEqual(exp, got[12]) {
+		t.Errorf("expected allowed values %v, got %v", exp, got[12])
+	}
+	if !reflect.DeepEqual(cage, got[13]) {
+		t.Errorf("expected cage %v, got %v", cage, got[13])
+	}
+}